@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+func writeConferences(w io.Writer, ratings schedule.ConferenceRatings) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RANK\tCONFERENCE\tTEAMS\tRPI\tNC RECORD\tNC RPI")
+
+	for i, r := range ratings {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%d\t%.4f\t%s\t%.4f\n",
+			i+1, r.Conference, r.Teams, r.RPI, r.NonConference.ToString(), r.NonConferenceRPI)
+	}
+
+	return tw.Flush()
+}
+
+// conferencesDescription is the detail printed by rpi conferences -h.
+const conferencesDescription = `Ranks the conferences by their members' RPI.  The teams file assigns
+each team its conference.`
+
+func runConferences(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("conferences", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi conferences -teams file [options] <file>", conferencesDescription)
+	opts := registerLoadFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 || opts.teamsFileName == "" {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	ratings, err := s.CalculateConferences()
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if err = writeConferences(stdout, ratings); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
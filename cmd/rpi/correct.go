@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/validation"
+)
+
+// correctDescription is the detail printed by rpi correct -h.
+const correctDescription = `Applies a corrections file to a results file and prints what changed.
+Every match is identified by its date and teams, such as
+2023-11-06-uconn-kansas, with a number added when the teams meet more
+than once that day.  Corrections files contain one correction per line
+in one of the forms
+
+  correct <id> <home>-<away> [annotation]
+  vacate <id>
+  replace <id> date,home,homeScore,away,awayScore[,location[,venue[,city]]]`
+
+func runCorrect(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("correct", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi correct [options] <file> <corrections>", correctDescription)
+	duplicates := flags.String("duplicates", validation.PolicyReport.String(),
+		"what to do with repeated or invalid matches: report, reject, keep-first or keep-last")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), &loadOptions{formulaName: schedule.DefaultFormula.Name, duplicates: *duplicates}, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	audit, err := applyCorrections(s, flags.Arg(1))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if err = audit.Write(stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: %d corrections, %d matches\n", flags.Arg(1), len(audit), len(s.GetMatches()))
+
+	return 0
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// sortContributions orders the matches by the named order: best, worst or date.
+func sortContributions(contributions schedule.Contributions, order string) error {
+	switch order {
+	case "best":
+		contributions.SortBestFirst()
+	case "worst":
+		contributions.SortWorstFirst()
+	case "date":
+		contributions.SortByDate()
+	default:
+		return fmt.Errorf("unknown order %s", order)
+	}
+
+	return nil
+}
+
+// decimal formats a value to four places, or - when it is undefined.
+func decimal(value float64) string {
+	if math.IsNaN(value) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.4f", value)
+}
+
+func writeExplanation(w io.Writer, explanation *schedule.Explanation) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Team:\t%s\n", explanation.Team)
+	_, _ = fmt.Fprintf(tw, "Record:\t%d-%d-%d\n", explanation.Wins, explanation.Losses, explanation.Ties)
+	_, _ = fmt.Fprintf(tw, "WP:\t%s\n", decimal(explanation.WP))
+	_, _ = fmt.Fprintf(tw, "OWP:\t%s\n", decimal(explanation.OWP))
+	_, _ = fmt.Fprintf(tw, "OOWP:\t%s\n", decimal(explanation.OOWP))
+	_, _ = fmt.Fprintf(tw, "RPI:\t%s\n", decimal(explanation.RPI))
+	_, _ = fmt.Fprintln(tw)
+
+	_, _ = fmt.Fprintln(tw, "DATE\tOPPONENT\tLOCATION\tRESULT\tSCORE\tWP\tOWP\tOOWP\tRPI\tRPI WITHOUT\tCHANGE\tID")
+	for _, c := range explanation.Contributions {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Match.Date.Format(dateLayout), c.Opponent, c.Match.LocationOf(explanation.Team), c.Result, c.Match.ToString(),
+			decimal(c.WP), decimal(c.OWP), decimal(c.OOWP), decimal(c.RPI), decimal(c.RPIWithout), signed(c.Change, 4), c.Match.ID)
+	}
+
+	return tw.Flush()
+}
+
+// explainDescription is the detail printed by rpi explain -h.
+const explainDescription = `Lists each of a team's matches with its share of the team's WP, OWP
+and OOWP, which add up to the elements, and the RPI the team would
+have if the match were removed.  -sort orders the matches from the best
+to the worst (best), the worst to the best (worst) or by date (date).`
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi explain [options] <file> <name>", explainDescription)
+	opts := registerLoadFlags(flags)
+	order := flags.String("sort", "best", "the order of the matches: best, worst or date")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	teamName := flags.Arg(1)
+	if registry := s.GetRegistry(); registry != nil {
+		if t, err := registry.Resolve(teamName); err == nil {
+			teamName = t.Name
+		}
+	}
+
+	explanation, err := s.Explain(teamName)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if err = sortContributions(explanation.Contributions, *order); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 2
+	}
+
+	if err = writeExplanation(stdout, explanation); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
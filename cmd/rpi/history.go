@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// parseWeekday parses the name of a day of the week such as "sunday" or "Sun".
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("unknown day %s", name)
+}
+
+// rankChange describes how a team's rank moved since the week before.
+func rankChange(standing *schedule.Standing) string {
+	change := standing.RankChange()
+	switch {
+	case standing.IsNew():
+		return "new"
+	case change == 0:
+		return "="
+	}
+
+	return fmt.Sprintf("%+d", change)
+}
+
+// rpiChange describes how a team's RPI moved since the week before, or - when it is new or either RPI
+// is undefined.
+func rpiChange(standing *schedule.Standing, precision int) string {
+	return signed(standing.RPIChange(), precision)
+}
+
+// signed formats a change with its sign, or - when it is undefined.
+func signed(change float64, precision int) string {
+	if math.IsNaN(change) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%+.*f", precision, change)
+}
+
+// standings returns the top standings of a week, or every standing when top is zero.
+func standings(week *schedule.Week, top int) []*schedule.Standing {
+	if top > 0 && top < len(week.Standings) {
+		return week.Standings[:top]
+	}
+
+	return week.Standings
+}
+
+func writeHistory(w io.Writer, history schedule.History, top int) error {
+	for i, week := range history {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		_, _ = fmt.Fprintf(w, "Week %d: %s to %s, %d matches\n",
+			week.Number, week.Start.Format(dateLayout), week.End.Format(dateLayout), week.Matches)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "RANK\tTEAM\tRECORD\tRPI\tCHANGE\tRPI CHANGE")
+
+		for _, standing := range standings(week, top) {
+			_, _ = fmt.Fprintf(tw, "%d\t%s\t%d-%d-%d\t%.4f\t%s\t%s\n",
+				standing.Rank, standing.Team, standing.Wins, standing.Losses, standing.Ties, standing.RPI,
+				rankChange(standing), rpiChange(standing, 4))
+		}
+
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeHistoryCSV writes a record per team per week, leaving the previous rank and the changes empty
+// for a team that wasn't ranked the week before.
+func writeHistoryCSV(w io.Writer, history schedule.History, top int) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"week", "start", "end", "rank", "team", "wins", "losses", "ties", "rpi", "previous_rank", "rank_change", "rpi_change"})
+
+	for _, week := range history {
+		for _, standing := range standings(week, top) {
+			previous, change, delta := "", "", ""
+			if !standing.IsNew() {
+				previous = strconv.Itoa(standing.PreviousRank)
+				change = strconv.Itoa(standing.RankChange())
+				delta = rpiChange(standing, 6)
+			}
+
+			_ = writer.Write([]string{
+				strconv.Itoa(week.Number), week.Start.Format(dateLayout), week.End.Format(dateLayout),
+				strconv.Itoa(standing.Rank), standing.Team,
+				strconv.Itoa(standing.Wins), strconv.Itoa(standing.Losses), strconv.Itoa(standing.Ties),
+				strconv.FormatFloat(standing.RPI, 'f', 6, 64), previous, change, delta,
+			})
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// historyDescription is the detail printed by rpi history -h.
+const historyDescription = `Ranks the teams at the end of each week, which ends on -week-ends, with
+each team's movement since the week before.`
+
+func runHistory(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi history [options] <file>", historyDescription)
+	opts := registerLoadFlags(flags)
+	top := flags.Int("top", 0, "only print the top N teams of each week (0 prints every team)")
+	weekEnds := flags.String("week-ends", "sunday", "the last day of each week")
+	formatName := flags.String("format", "text", "the output format: text or csv")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	weekEnd, err := parseWeekday(*weekEnds)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 2
+	}
+
+	write := writeHistory
+	switch *formatName {
+	case "text":
+	case "csv":
+		write = writeHistoryCSV
+	default:
+		_, _ = fmt.Fprintf(stderr, "rpi: unknown format %s\n", *formatName)
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	history, err := s.History(weekEnd)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if err = write(stdout, history, *top); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/correction"
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	"github.com/jedi-knights/rpi/pkg/validation"
+)
+
+// readMatches parses every match in a results file.  Records that fail to parse are
+// reported as errors and skipped, so callers can decide whether a partial schedule is usable.
+// When a resolver is given each team name is resolved through it and unknown teams are errors.
+func readMatches(r io.Reader, fileName string, teams importer.TeamResolver) ([]*match.Match, []error) {
+	matches, lineErrors := importer.ReadCSV(r, importer.Options{FileName: fileName, Teams: teams})
+
+	errs := make([]error, 0, len(lineErrors))
+	for _, err := range lineErrors {
+		errs = append(errs, err)
+	}
+
+	return matches, errs
+}
+
+// loadRegistry reads a teams file listing every team with its division.
+func loadRegistry(fileName string) (*team.Registry, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	registry, err := team.LoadRegistry(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return registry, nil
+}
+
+// dateLayout is the layout of dates given on the command line.
+const dateLayout = "2006-01-02"
+
+// loadOptions is how a results file is loaded into a schedule.
+type loadOptions struct {
+	formulaName         string
+	teamsFileName       string
+	correctionsFileName string
+	duplicates          string
+	fuzzy               float64
+	asOf                string
+}
+
+// registerLoadFlags adds the flags that control how a results file is loaded.
+func registerLoadFlags(flags *flag.FlagSet) *loadOptions {
+	opts := &loadOptions{}
+	flags.StringVar(&opts.formulaName, "formula", schedule.DefaultFormula.Name, "the RPI formula to use")
+	flags.StringVar(&opts.teamsFileName, "teams", "", "a teams file listing every team; names are resolved through it and only Division I matches are rated")
+	flags.StringVar(&opts.correctionsFileName, "corrections", "", "a corrections file to apply to the results")
+	flags.StringVar(&opts.duplicates, "duplicates", validation.PolicyReport.String(),
+		"what to do with repeated or invalid matches: report, reject, keep-first or keep-last")
+	flags.Float64Var(&opts.fuzzy, "fuzzy", 0,
+		"match team names missing from the teams file to names at least this similar, from 0 to 1")
+	flags.StringVar(&opts.asOf, "as-of", "", "only rate the matches played on or before this date, such as 2023-10-01")
+
+	return opts
+}
+
+// newSchedule returns an empty schedule that uses the named formula.  When a teams file is named only
+// matches between Division I teams are rated and every team must be listed in it.
+func newSchedule(opts *loadOptions) (*schedule.Schedule, error) {
+	formula, err := schedule.LookupFormula(opts.formulaName)
+	if err != nil {
+		return nil, err
+	}
+
+	s := schedule.NewSchedule()
+	if err = s.SetFormula(formula); err != nil {
+		return nil, err
+	}
+
+	if opts.teamsFileName != "" {
+		registry, err := loadRegistry(opts.teamsFileName)
+		if err != nil {
+			return nil, err
+		}
+
+		s.SetRegistry(registry)
+	}
+
+	return s, nil
+}
+
+// loadSchedule reads a results file into a schedule, failing on the first invalid line.  Repeated and
+// invalid matches are handled by the duplicates policy, and any that are kept are written to warnings.
+// When a corrections file is named its corrections are applied, and when a date is given only the
+// matches played by then are kept.
+func loadSchedule(fileName string, opts *loadOptions, warnings io.Writer) (*schedule.Schedule, error) {
+	policy, err := validation.ParsePolicy(opts.duplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newSchedule(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	teams, resolver, err := teamResolver(s.GetRegistry(), opts.fuzzy)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, errs := readMatches(file, fileName, teams)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if resolver != nil {
+		for _, m := range resolver.Review() {
+			if m.Decision == team.DecisionApplied {
+				_, _ = fmt.Fprintf(warnings, "rpi: warning: %s: matched %v\n", fileName, m)
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s: no matches found", fileName)
+	}
+
+	issues, err := s.AddMatches(matches, policy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	for _, issue := range issues {
+		_, _ = fmt.Fprintf(warnings, "rpi: warning: %s: %v\n", fileName, issue)
+	}
+
+	if opts.correctionsFileName != "" {
+		if _, err = applyCorrections(s, opts.correctionsFileName); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.asOf != "" {
+		cutoff, err := time.Parse(dateLayout, opts.asOf)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date <%s>", opts.asOf)
+		}
+
+		s = s.AsOf(cutoff)
+	}
+
+	return s, nil
+}
+
+// teamResolver returns what team names are resolved through: nothing without a registry, the registry
+// itself, or a fuzzy resolver over it when threshold isn't zero.  The fuzzy resolver is also returned
+// so its decisions can be reviewed.
+func teamResolver(registry *team.Registry, threshold float64) (importer.TeamResolver, *team.Resolver, error) {
+	if threshold == 0 {
+		if registry == nil {
+			return nil, nil, nil
+		}
+
+		return registry, nil, nil
+	}
+
+	if registry == nil {
+		return nil, nil, fmt.Errorf("-fuzzy requires -teams")
+	}
+
+	if threshold < 0 || threshold > 1 {
+		return nil, nil, fmt.Errorf("invalid fuzzy threshold <%v>", threshold)
+	}
+
+	resolver := team.NewResolver(registry, threshold)
+
+	return resolver, resolver, nil
+}
+
+// applyCorrections reads a corrections file and applies it to the schedule.
+func applyCorrections(s *schedule.Schedule, fileName string) (correction.Audit, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	corrections, err := correction.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	audit, err := correction.Apply(s, corrections)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return audit, nil
+}
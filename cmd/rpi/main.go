@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `Usage: rpi <command> [options]
+
+Commands:
+  rank         print the RPI ranking of every team
+  team         print the RPI breakdown for a single team
+  explain      print what each of a team's matches adds to its RPI
+  history      print the ranking at the end of each week with its movement
+  project      project every team's RPI at the end of the season
+  simulate     simulate the rest of the season and print each team's chances
+  scenario     print how a scenario's hypothetical results move each team
+  conferences  rank the conferences by their members' RPI
+  validate     check that every line of a results file parses
+  names        review how each team name maps to the teams file
+  correct      apply a corrections file and print what changed
+  serve        serve the schedule and its rankings as a JSON API
+
+Run rpi <command> -h for a command's options and the files it reads.
+`
+
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"rank":        runRank,
+	"team":        runTeam,
+	"validate":    runValidate,
+	"names":       runNames,
+	"history":     runHistory,
+	"project":     runProject,
+	"simulate":    runSimulate,
+	"scenario":    runScenario,
+	"explain":     runExplain,
+	"conferences": runConferences,
+	"correct":     runCorrect,
+	"serve":       runServe,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		_, _ = fmt.Fprint(stdout, usage)
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "rpi: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	return cmd(args[1:], stdout, stderr)
+}
+
+// setUsage makes the command print its synopsis, a description and its flags when it is given -h or
+// the wrong arguments.
+func setUsage(flags *flag.FlagSet, synopsis, description string) {
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(flags.Output(), "usage: %s\n\n%s\n\nOptions:\n", synopsis, description)
+		flags.PrintDefaults()
+	}
+}
@@ -0,0 +1,845 @@
+package main
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const results = `# 2023 sample
+2023-11-06,UConn,64,Kansas,57
+2023-11-10,UConn,82,Duke,68
+2023-11-14,Wisconsin,71,UConn,72
+
+2023-11-20,Kansas,69,UConn,62
+2023-11-24,Duke,81,Wisconsin,70,N,Madison Square Garden,New York
+2023-11-28,Wisconsin,52,Kansas,62
+`
+
+var _ = Describe("rpi", func() {
+	var stdout, stderr *bytes.Buffer
+	var fileName string
+
+	writeFile := func(contents string) string {
+		name := filepath.Join(GinkgoT().TempDir(), "results.csv")
+		Expect(os.WriteFile(name, []byte(contents), 0o600)).To(Succeed())
+		return name
+	}
+
+	BeforeEach(func() {
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+		fileName = writeFile(results)
+	})
+
+	It("should print usage and fail without a command", func() {
+		// Act
+		code := run(nil, stdout, stderr)
+
+		// Assert
+		Expect(code).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("Usage: rpi"))
+	})
+
+	It("should print a command's detail and options with -h", func() {
+		// Act
+		code := run([]string{"explain", "-h"}, stdout, stderr)
+
+		// Assert
+		Expect(code).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("usage: rpi explain [options] <file> <name>"))
+		Expect(stderr.String()).To(ContainSubstring("the RPI the team would"))
+		Expect(stderr.String()).To(ContainSubstring("-sort string"))
+	})
+
+	It("should reject an unknown command", func() {
+		// Act
+		code := run([]string{"foo"}, stdout, stderr)
+
+		// Assert
+		Expect(code).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring(`unknown command "foo"`))
+	})
+
+	Describe("rank", func() {
+		It("should print every team ordered by RPI", func() {
+			// Act
+			code := run([]string{"rank", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stderr.String()).To(BeEmpty())
+
+			lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+			Expect(lines).To(HaveLen(5))
+			Expect(string(lines[0])).To(MatchRegexp(`^RANK\s+TEAM\s+RECORD\s+WP\s+OWP\s+OOWP\s+RPI$`))
+			Expect(string(lines[1])).To(MatchRegexp(`^1\s+UConn\s+3-1-0\s+0\.7500\s+0\.7500\s+0\.5139\s+0\.6910$`))
+			Expect(string(lines[2])).To(MatchRegexp(`^2\s+Kansas\s+2-1-0\s`))
+			Expect(string(lines[3])).To(MatchRegexp(`^3\s+Duke\s+1-1-0\s`))
+			Expect(string(lines[4])).To(MatchRegexp(`^4\s+Wisconsin\s+0-3-0\s`))
+		})
+
+		It("should limit the output to the top N teams", func() {
+			// Act
+			code := run([]string{"rank", "-top", "2", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(bytes.Count(stdout.Bytes(), []byte("\n"))).To(Equal(3))
+		})
+
+		It("should use the requested formula", func() {
+			// Act
+			code := run([]string{"rank", "-formula", "ice-hockey", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`1\s+Kansas\s+2-1-0\s+0\.6667\s+0\.6667\s+0\.6296\s+0\.6467`))
+			Expect(stdout.String()).To(MatchRegexp(`2\s+UConn\s+3-1-0\s+0\.7500\s+0\.7500\s+0\.5139\s+0\.6225`))
+		})
+
+		It("should print the bonus and penalty adjustments", func() {
+			// Act
+			code := run([]string{"rank", "-adjusted", fileName}, stdout, stderr)
+
+			// Assert
+			// With only four teams every opponent is in the top and bottom 40.
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`RPI\s+BONUS\s+PENALTY\s+ARPI\n`))
+			Expect(stdout.String()).To(MatchRegexp(`UConn\s+3-1-0(\s+\d\.\d{4}){4}\s+0\.0088\s+-0\.0028\s+0\.6970`))
+		})
+
+		It("should only rate matches between Division I teams", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\nWisconsin,II\n")
+			fileName = writeFile(results + "2023-12-02,Kansas,60,Duke,66\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stderr.String()).To(BeEmpty())
+
+			lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+			Expect(lines).To(HaveLen(4))
+			Expect(stdout.String()).To(MatchRegexp(`\n\d\s+UConn\s+2-1-0\s`))
+			Expect(stdout.String()).NotTo(ContainSubstring("Wisconsin"))
+			Expect(stdout.String()).NotTo(ContainSubstring("NaN"))
+		})
+
+		It("should fail for an invalid teams file", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,X\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("line 1: unknown division <X>"))
+		})
+
+		It("should resolve aliases through the teams file", func() {
+			// Arrange
+			teamsFileName := writeFile("id,name,aliases,division\nuconn,UConn,Connecticut|UCONN,I\nku,Kansas,KU,I\nduke,Duke,,I\nwis,Wisconsin,,I\n")
+			fileName = writeFile(results + "2023-12-02,Connecticut,70,KU,60\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`UConn\s+4-1-0\s`))
+			Expect(stdout.String()).NotTo(ContainSubstring("Connecticut"))
+		})
+
+		It("should fail for a team missing from the teams file", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("unknown team Wisconsin"))
+		})
+
+		It("should render the ranking in another format", func() {
+			// Act
+			code := run([]string{"rank", "-format", "csv", "-columns", "rank,team,rpi", "-precision", "3", "-top", "1", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(Equal("Rank,Team,RPI\n1,UConn,0.691\n"))
+		})
+
+		It("should render whole numbers with a precision of 0", func() {
+			// Act
+			code := run([]string{"rank", "-format", "csv", "-columns", "rank,team,rpi", "-precision", "0", "-top", "1", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(Equal("Rank,Team,RPI\n1,UConn,1\n"))
+		})
+
+		It("should reject an unknown format", func() {
+			// Act
+			code := run([]string{"rank", "-format", "pdf", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("unknown format pdf"))
+		})
+
+		It("should fail for an unknown formula", func() {
+			// Act
+			code := run([]string{"rank", "-formula", "curling", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("unknown formula curling"))
+		})
+
+		It("should fail when the file contains an invalid line", func() {
+			// Arrange
+			fileName = writeFile("2023-11-06,UConn,64,Kansas,57\n2023-11-10,UConn,xx,Duke,68\n")
+
+			// Act
+			code := run([]string{"rank", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring(":2: unable to parse match"))
+		})
+	})
+
+	Describe("team", func() {
+		It("should print the breakdown for a single team", func() {
+			// Act
+			code := run([]string{"team", fileName, "Duke"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`Rank:\s+3 of 4`))
+			Expect(stdout.String()).To(MatchRegexp(`Record:\s+1-1-0`))
+			Expect(stdout.String()).To(MatchRegexp(`OWP:\s+0\.3333`))
+			Expect(stdout.String()).To(MatchRegexp(`Home:\s+0-0-0`))
+			Expect(stdout.String()).To(MatchRegexp(`Away:\s+0-1-0`))
+			Expect(stdout.String()).To(MatchRegexp(`Neutral:\s+1-0-0`))
+			Expect(stdout.String()).To(MatchRegexp(`2023-11-10\s+UConn\s+away\s+L\s+UConn,82,Duke,68`))
+			Expect(stdout.String()).To(MatchRegexp(`2023-11-24\s+Wisconsin\s+neutral\s+W\s+Duke,81,Wisconsin,70`))
+		})
+
+		It("should print the full and Division I records", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\nWisconsin,II\n")
+
+			// Act
+			code := run([]string{"team", "-teams", teamsFileName, fileName, "UConn"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`Record:\s+3-1-0\n`))
+			Expect(stdout.String()).To(MatchRegexp(`Division I Record:\s+2-1-0\n`))
+		})
+
+		It("should fail for a team that isn't eligible", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\nWisconsin,II\n")
+
+			// Act
+			code := run([]string{"team", "-teams", teamsFileName, fileName, "Wisconsin"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("team Wisconsin is not eligible for the RPI"))
+		})
+
+		It("should fail for an eligible team without rated matches", func() {
+			// Arrange
+			fileName = writeFile("2023-11-06,UConn,64,Kansas,57\n2023-11-10,Duke,1,Emory,0\n")
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\nEmory,III\n")
+
+			// Act
+			code := run([]string{"team", "-teams", teamsFileName, fileName, "Duke"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stdout.String()).To(BeEmpty())
+			Expect(stderr.String()).To(ContainSubstring("team Duke has no rated matches"))
+		})
+
+		It("should fail for a team that doesn't exist", func() {
+			// Act
+			code := run([]string{"team", fileName, "Foo"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("no matches found for team Foo"))
+		})
+	})
+
+	Describe("correct", func() {
+		It("should apply the corrections and print what changed", func() {
+			// Arrange
+			correctionsFileName := writeFile("correct 2023-11-06-uconn-kansas 64-65 (OT)\nvacate 2023-11-28-wisconsin-kansas\n")
+
+			// Act
+			code := run([]string{"correct", fileName, correctionsFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(Equal(
+				"line 1: corrected 2023-11-06-uconn-kansas: 2023-11-06 UConn,64,Kansas,57 -> 2023-11-06 UConn,64,Kansas,65 (OT)\n" +
+					"line 2: vacated 2023-11-28-wisconsin-kansas: 2023-11-28 Wisconsin,52,Kansas,62\n" +
+					correctionsFileName + ": 2 corrections, 5 matches\n"))
+		})
+
+		It("should fail for a correction that names a missing match", func() {
+			// Arrange
+			correctionsFileName := writeFile("vacate 2023-11-06-uconn-duke\n")
+
+			// Act
+			code := run([]string{"correct", fileName, correctionsFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring(correctionsFileName + ": line 1: no match with id 2023-11-06-uconn-duke"))
+		})
+
+		It("should apply corrections before ranking", func() {
+			// Arrange
+			correctionsFileName := writeFile("correct 2023-11-20-kansas-uconn 61-62\n")
+
+			// Act
+			code := run([]string{"team", "-corrections", correctionsFileName, fileName, "UConn"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`Record:\s+4-0-0`))
+			Expect(stdout.String()).To(MatchRegexp(`Kansas,61,UConn,62\s+2023-11-20-kansas-uconn\n`))
+		})
+	})
+
+	Describe("serve", func() {
+		It("should serve the schedule", func() {
+			// Arrange
+			original := serve
+			DeferCleanup(func() { serve = original })
+
+			var handler http.Handler
+			serve = func(listener net.Listener, h http.Handler) error {
+				handler = h
+				return listener.Close()
+			}
+
+			// Act
+			code := run([]string{"serve", "-addr", "127.0.0.1:0", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`^rpi: serving 6 matches on http://127\.0\.0\.1:\d+\n$`))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/teams/UConn", nil))
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should fail for an unknown formula", func() {
+			// Act
+			code := run([]string{"serve", "-formula", "quidditch"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("unknown formula quidditch"))
+		})
+	})
+
+	Describe("history", func() {
+		It("should print the ranking at the end of each week", func() {
+			// Act
+			code := run([]string{"history", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(HavePrefix("Week 1: 2023-11-06 to 2023-11-12, 2 matches\nRANK"))
+			Expect(stdout.String()).To(ContainSubstring("Week 4: 2023-11-27 to 2023-12-03, 6 matches\n"))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+UConn\s+3-1-0\s+0\.6910\s+\+2\s+-\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\d\s+Wisconsin\s+0-1-0\s+\S+\s+new\s+-\n`))
+		})
+
+		It("should write the history as CSV", func() {
+			// Act
+			code := run([]string{"history", "-format", "csv", "-top", "1", "-week-ends", "sat", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			Expect(lines[0]).To(Equal("week,start,end,rank,team,wins,losses,ties,rpi,previous_rank,rank_change,rpi_change"))
+			Expect(lines).To(HaveLen(5))
+			Expect(lines[4]).To(HavePrefix("4,2023-11-26,2023-12-02,1,UConn,3,1,0,0.690972,"))
+		})
+
+		It("should reject an unknown day", func() {
+			// Act
+			code := run([]string{"history", "-week-ends", "someday", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("unknown day someday"))
+		})
+	})
+
+	Describe("project", func() {
+		const fixtures = "2023-12-02,Kansas,,Duke,\n2023-12-05,Duke,,UConn,\n"
+
+		It("should rate the results without the fixtures", func() {
+			// Arrange
+			fileName = writeFile(results + fixtures)
+
+			// Act
+			code := run([]string{"rank", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+UConn\s+3-1-0\s+0\.7500\s+0\.7500\s+0\.5139\s+0\.6910\n`))
+		})
+
+		It("should project every team's RPI with the favorites winning", func() {
+			// Arrange
+			fileName = writeFile(results + fixtures)
+
+			// Act
+			code := run([]string{"project", "-fixtures", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`^RANK\s+TEAM\s+RECORD\s+RPI\s+CURRENT RPI\s+CHANGE\s+REMAINING\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+UConn\s+4-1-0\s+\d\.\d{4}\s+0\.6910\s+-\d\.\d{4}\s+1\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n4\s+Wisconsin\s+0-3-0\s+\d\.\d{4}\s+0\.3403\s+\+\d\.\d{4}\s+0\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n2023-12-05\s+Duke,0,UConn,1\s+predicted\s+2023-12-05-duke-uconn\n`))
+		})
+
+		It("should use the supplied outcomes", func() {
+			// Arrange
+			fileName = writeFile(results + fixtures)
+			outcomesFileName := filepath.Join(GinkgoT().TempDir(), "outcomes.txt")
+			Expect(os.WriteFile(outcomesFileName, []byte("2023-12-05-duke-uconn 75-70\n"), 0o600)).To(Succeed())
+
+			// Act
+			code := run([]string{"project", "-fixtures", "-outcomes", outcomesFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`\d\s+Duke\s+2-2-0\s`))
+			Expect(stdout.String()).To(MatchRegexp(`\n2023-12-05\s+Duke,75,UConn,70\s+supplied\s+2023-12-05-duke-uconn\n`))
+		})
+
+		It("should fail for an outcome of a match that was played", func() {
+			// Arrange
+			outcomesFileName := filepath.Join(GinkgoT().TempDir(), "outcomes.txt")
+			Expect(os.WriteFile(outcomesFileName, []byte("2023-11-06-uconn-kansas 0-1\n"), 0o600)).To(Succeed())
+
+			// Act
+			code := run([]string{"project", "-outcomes", outcomesFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("match 2023-11-06-uconn-kansas isn't a fixture to be played"))
+		})
+	})
+
+	Describe("simulate", func() {
+		const fixtures = "2023-12-02,Kansas,,Duke,\n2023-12-05,Duke,,UConn,\n"
+
+		It("should print each team's chances of finishing inside the cutoffs", func() {
+			// Arrange
+			fileName = writeFile(results + fixtures)
+
+			// Act
+			code := run([]string{"simulate", "-iterations", "200", "-seed", "5", "-cutoffs", "1,2", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(HavePrefix("200 seasons, 2 fixtures, seed 5\n"))
+			Expect(stdout.String()).To(MatchRegexp(`\nRANK\s+TEAM\s+MEAN RANK\s+BEST\s+WORST\s+MEDIAN RPI\s+TOP 1\s+TOP 2\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+UConn\s+1\.\d\d\s+1\s+\d\s+\d\.\d{4}\s+\d+\.\d%\s+100\.0%\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n4\s+Wisconsin\s+4\.00\s+4\s+4\s+\d\.\d{4}\s+0\.0%\s+0\.0%\n`))
+		})
+
+		It("should repeat a simulation with the same seed", func() {
+			// Arrange
+			fileName = writeFile(results + fixtures)
+			Expect(run([]string{"simulate", "-iterations", "50", "-seed", "9", "-workers", "1", fileName}, stdout, stderr)).To(Equal(0))
+			first := stdout.String()
+			stdout.Reset()
+
+			// Act
+			code := run([]string{"simulate", "-iterations", "50", "-seed", "9", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(Equal(first))
+		})
+
+		It("should reject an invalid cutoff", func() {
+			// Act
+			code := run([]string{"simulate", "-cutoffs", "0", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("invalid cutoff <0>"))
+		})
+	})
+
+	Describe("scenario", func() {
+		writeScenario := func(name, contents string) string {
+			scenarioFileName := filepath.Join(GinkgoT().TempDir(), name)
+			Expect(os.WriteFile(scenarioFileName, []byte(contents), 0o600)).To(Succeed())
+			return scenarioFileName
+		}
+
+		It("should print each team the hypothetical results move", func() {
+			// Arrange
+			scenarioFileName := writeScenario("scenario.yaml", `name: Kansas sweeps
+results:
+  2023-11-06-uconn-kansas: 57-64
+matches:
+  - date: 2023-12-02
+    home: Kansas
+    away: Duke
+    score: 2-1 (OT)
+`)
+
+			// Act
+			code := run([]string{"scenario", fileName, scenarioFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(HavePrefix("Scenario: Kansas sweeps\nRANK  TEAM"))
+			Expect(stdout.String()).To(MatchRegexp(`\nRANK\s+TEAM\s+RECORD\s+RPI\s+BEFORE RANK\s+BEFORE RPI\s+CHANGE\s+RPI CHANGE\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+Kansas\s+4-0-0\s+\d\.\d{4}\s+\d\s+\d\.\d{4}\s+\+\d\s+\+\d\.\d{4}\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\d\s+UConn\s+2-2-0\s+\d\.\d{4}\s+1\s+0\.6910\s+-\d\s+-\d\.\d{4}\n`))
+		})
+
+		It("should report a scenario that changes nothing", func() {
+			// Arrange
+			scenarioFileName := writeScenario("scenario.json", `{"results": {"2023-11-06-uconn-kansas": "64-57"}}`)
+
+			// Act
+			code := run([]string{"scenario", fileName, scenarioFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(Equal("No team's rating or rank changes.\n"))
+		})
+
+		It("should fail for a result of a match that isn't in the schedule", func() {
+			// Arrange
+			scenarioFileName := writeScenario("scenario.yml", "results:\n  2023-12-25-uconn-duke: 1-0\n")
+
+			// Act
+			code := run([]string{"scenario", fileName, scenarioFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("no match with id 2023-12-25-uconn-duke"))
+		})
+
+		It("should resolve the hypothetical teams through the teams file", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I\nKansas,I\nDuke,I\nWisconsin,I\n")
+			scenarioFileName := writeScenario("scenario.yaml", "matches:\n  - home: Gonzaga\n    away: Duke\n    score: 1-0\n")
+
+			// Act
+			code := run([]string{"scenario", "-teams", teamsFileName, fileName, scenarioFileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("match 1:"))
+			Expect(stderr.String()).To(ContainSubstring("Gonzaga"))
+		})
+
+		It("should require a results file and a scenario file", func() {
+			// Act
+			code := run([]string{"scenario", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("usage: rpi scenario"))
+		})
+	})
+
+	Describe("explain", func() {
+		It("should list each match's contribution from the best to the worst", func() {
+			// Act
+			code := run([]string{"explain", fileName, "UConn"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(HavePrefix("Team:    UConn\nRecord:  3-1-0\n"))
+			Expect(stdout.String()).To(ContainSubstring("RPI:     0.6910\n"))
+			Expect(stdout.String()).To(MatchRegexp(`\nDATE\s+OPPONENT\s+LOCATION\s+RESULT\s+SCORE\s+WP\s+OWP\s+OOWP\s+RPI\s+RPI WITHOUT\s+CHANGE\s+ID\n` +
+				`2023-11-06\s+Kansas\s+home\s+W\s+UConn,64,Kansas,57\s+0\.2500\s+0\.2500\s+0\.1667\s+0\.2292\s+0\.6042\s+-0\.0868\s+2023-11-06-uconn-kansas\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n2023-11-14\s+Wisconsin\s+away\s+W\s+\S+\s+0\.2500\s+0\.0000\s+0\.0972\s+0\.0868\s+0\.7986\s+\+0\.1076\s+2023-11-14-wisconsin-uconn\n` +
+				`2023-11-10\s+Duke\s+home\s+W\s+\S+\s+0\.2500\s+0\.2500\s+0\.0833\s+0\.2083\s+-\s+-\s+2023-11-10-uconn-duke\n$`))
+		})
+
+		It("should put the worst match first", func() {
+			// Act
+			code := run([]string{"explain", "-sort", "worst", fileName, "UConn"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`CHANGE\s+ID\n2023-11-14\s+Wisconsin\s`))
+		})
+
+		It("should reject an unknown order", func() {
+			// Act
+			code := run([]string{"explain", "-sort", "random", fileName, "UConn"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("unknown order random"))
+		})
+
+		It("should fail for a team without matches", func() {
+			// Act
+			code := run([]string{"explain", fileName, "Gonzaga"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("no matches found for team Gonzaga"))
+		})
+	})
+
+	Describe("-as-of", func() {
+		It("should only rate the matches played by the date", func() {
+			// Act
+			code := run([]string{"rank", "-as-of", "2023-11-14", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`UConn\s+3-0-0\s`))
+			Expect(stdout.String()).To(MatchRegexp(`Kansas\s+0-1-0\s`))
+		})
+
+		It("should reject an invalid date", func() {
+			// Act
+			code := run([]string{"rank", "-as-of", "11/14/2023", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("invalid date <11/14/2023>"))
+		})
+	})
+
+	Describe("conferences", func() {
+		const teams = "UConn,I,Big East\nKansas,I,Big 12\nDuke,I,ACC\nWisconsin,I,Big Ten\n"
+
+		It("should rank the conferences", func() {
+			// Arrange
+			teamsFileName := writeFile(teams)
+
+			// Act
+			code := run([]string{"conferences", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`^RANK\s+CONFERENCE\s+TEAMS\s+RPI\s+NC RECORD\s+NC RPI\n`))
+			Expect(stdout.String()).To(MatchRegexp(`\n1\s+Big East\s+1\s+0\.6910\s+3-1-0\s+0\.6910\n`))
+		})
+
+		It("should require a teams file", func() {
+			// Act
+			code := run([]string{"conferences", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("usage: rpi conferences"))
+		})
+
+		It("should add the conference views to the ranking", func() {
+			// Arrange
+			teamsFileName := writeFile("UConn,I,East\nKansas,I,East\nDuke,I,West\nWisconsin,I,West\n")
+
+			// Act
+			code := run([]string{"rank", "-conferences", "-teams", teamsFileName, fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`RPI\s+CONFERENCE\s+CONF\s+CONF RPI\s+NC\s+NC RPI\n`))
+			Expect(stdout.String()).To(MatchRegexp(`UConn\s+3-1-0(\s+\d\.\d{4}){4}\s+East\s+1-1-0\s+\d\.\d{4}\s+2-0-0\s+\d\.\d{4}\n`))
+		})
+
+		It("should fail to add the conference views without a teams file", func() {
+			// Act
+			code := run([]string{"rank", "-conferences", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("conference splits require a registry"))
+		})
+	})
+
+	Describe("names", func() {
+		const teams = "UConn,I\nKansas,I\nDuke,I\nWisconsin,I\n"
+
+		It("should review how each name maps to the teams file", func() {
+			// Arrange
+			teamsFileName := writeFile(teams)
+			fileName = writeFile(results + "2023-12-02,UCONN,70,Kansass,60\n2023-12-05,Duke,70,Gonzaga,60\n")
+
+			// Act
+			code := run([]string{"names", "-teams", teamsFileName, "-fuzzy", "0.8", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stdout.String()).To(MatchRegexp(`UCONN\s+UConn\s+exact\s+1\.00\s+1\n`))
+			Expect(stdout.String()).To(MatchRegexp(`Kansass\s+Kansas\s+applied\s+0\.86\s+1\n`))
+			Expect(stdout.String()).To(MatchRegexp(`Gonzaga\s+-\s+unknown\s+0\.00\s+1\n`))
+			Expect(stdout.String()).To(ContainSubstring(": 7 names, 1 unresolved\n"))
+		})
+
+		It("should require a teams file", func() {
+			// Act
+			code := run([]string{"names", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(2))
+			Expect(stderr.String()).To(ContainSubstring("usage: rpi names"))
+		})
+
+		It("should match close names when ranking with -fuzzy", func() {
+			// Arrange
+			teamsFileName := writeFile(teams)
+			fileName = writeFile(results + "2023-12-02,Kansass,60,UConn,70\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, "-fuzzy", "0.8", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stderr.String()).To(ContainSubstring("warning: " + fileName + ": matched Kansass -> Kansas (applied, 0.86)"))
+			Expect(stdout.String()).To(MatchRegexp(`UConn\s+4-1-0\s`))
+			Expect(stdout.String()).NotTo(ContainSubstring("Kansass"))
+		})
+
+		It("should suggest a close name below the -fuzzy threshold", func() {
+			// Arrange
+			teamsFileName := writeFile(teams)
+			fileName = writeFile(results + "2023-12-02,Kansass,60,UConn,70\n")
+
+			// Act
+			code := run([]string{"rank", "-teams", teamsFileName, "-fuzzy", "0.9", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("unknown team Kansass, did you mean Kansas?"))
+		})
+	})
+
+	Describe("validate", func() {
+		It("should accept a header row and quoted team names", func() {
+			// Arrange
+			fileName = writeFile("home,away,home score,away score,date\n\"California, Berkeley\",Stanford,1,2,11/02/2023\n")
+
+			// Act
+			code := run([]string{"validate", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(ContainSubstring("1 matches, ok"))
+		})
+
+		It("should report a valid file", func() {
+			// Act
+			code := run([]string{"validate", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(ContainSubstring("6 matches, ok"))
+		})
+
+		It("should report every invalid line", func() {
+			// Arrange
+			fileName = writeFile("2023-11-06,UConn,64,Kansas,57\nnot a match\n2023-13-01,UConn,1,Duke,0\n")
+
+			// Act
+			code := run([]string{"validate", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring(":2: unable to parse match"))
+			Expect(stderr.String()).To(ContainSubstring(":3: unable to parse match"))
+			Expect(stdout.String()).To(ContainSubstring("1 matches, 2 errors"))
+		})
+
+		It("should report repeated and invalid matches", func() {
+			// Arrange
+			fileName = writeFile(results + "2023-11-06,Kansas,57,UConn,64\n2023-11-30,Duke,-1,Kansas,0\n")
+
+			// Act
+			code := run([]string{"validate", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring(fileName + ": 2023-11-06 Kansas,57,UConn,64: mirrored duplicate of 2023-11-06 UConn,64,Kansas,57\n"))
+			Expect(stderr.String()).To(ContainSubstring(fileName + ": 2023-11-30 Duke,-1,Kansas,0: the home score is negative\n"))
+			Expect(stdout.String()).To(ContainSubstring("8 matches, 2 errors"))
+		})
+	})
+
+	Describe("duplicates", func() {
+		BeforeEach(func() {
+			fileName = writeFile(results + "2023-11-06,Kansas,58,UConn,64\n")
+		})
+
+		It("should warn about repeated matches and rate every match by default", func() {
+			// Act
+			code := run([]string{"team", fileName, "Kansas"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stderr.String()).To(Equal("rpi: warning: " + fileName + ": 2023-11-06 Kansas,58,UConn,64: conflicts with 2023-11-06 UConn,64,Kansas,57\n"))
+			Expect(stdout.String()).To(MatchRegexp(`Record:\s+2-2-0`))
+		})
+
+		It("should keep the last report of a repeated match", func() {
+			// Act
+			code := run([]string{"team", "-duplicates", "keep-last", fileName, "Kansas"}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(0))
+			Expect(stdout.String()).To(MatchRegexp(`Record:\s+2-1-0`))
+		})
+
+		It("should fail when rejecting repeated matches", func() {
+			// Act
+			code := run([]string{"rank", "-duplicates", "reject", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(Equal("rpi: " + fileName + ": 2023-11-06 Kansas,58,UConn,64: conflicts with 2023-11-06 UConn,64,Kansas,57\n"))
+			Expect(stdout.String()).To(BeEmpty())
+		})
+
+		It("should fail for an unknown policy", func() {
+			// Act
+			code := run([]string{"rank", "-duplicates", "ignore", fileName}, stdout, stderr)
+
+			// Assert
+			Expect(code).To(Equal(1))
+			Expect(stderr.String()).To(ContainSubstring("unknown policy ignore"))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jedi-knights/rpi/pkg/team"
+)
+
+// defaultFuzzy is the similarity the names command applies aliases at unless told otherwise.
+const defaultFuzzy = 0.85
+
+// namesDescription is the detail printed by rpi names -h.
+const namesDescription = `Prints how each team name in a results file maps to the teams file.  A
+teams file lists one team per line in the form
+name,division[,conference[,region]], or starts with a header naming the
+columns id, name, aliases, conference, division and region with aliases
+separated by |.  Names are compared ignoring case, punctuation and
+accents, with St. read as Saint or State, and a name that isn't listed
+is matched to the most similar listed name at least -fuzzy similar.`
+
+func runNames(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("names", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi names -teams file [options] <file>", namesDescription)
+	teamsFileName := flags.String("teams", "", "the teams file to resolve names through")
+	fuzzy := flags.Float64("fuzzy", defaultFuzzy, "match names to registered names at least this similar, from 0 to 1")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 || *teamsFileName == "" {
+		flags.Usage()
+		return 2
+	}
+
+	registry, err := loadRegistry(*teamsFileName)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	teams, resolver, err := teamResolver(registry, *fuzzy)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if resolver == nil {
+		// A threshold of zero only resolves names exactly, so review them the same way.
+		resolver = team.NewResolver(registry, 2)
+		teams = resolver
+	}
+
+	fileName := flags.Arg(0)
+	file, err := os.Open(fileName)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	// Unresolved names are counted from the review rather than reported line by line.
+	_, _ = readMatches(file, fileName, teams)
+
+	review := resolver.Review()
+	if err = review.Write(stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	unresolved := 0
+	for _, m := range review {
+		if m.Decision == team.DecisionSuggested || m.Decision == team.DecisionUnknown {
+			unresolved++
+		}
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: %d names, %d unresolved\n", fileName, len(review), unresolved)
+	if unresolved > 0 {
+		return 1
+	}
+
+	return 0
+}
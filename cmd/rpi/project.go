@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// loadOutcomes reads an outcomes file giving the results of some fixtures.
+func loadOutcomes(fileName string) (schedule.Outcomes, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	outcomes, err := schedule.ParseOutcomes(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return outcomes, nil
+}
+
+// currentRPI returns a team's RPI over the matches played so far, or - when it isn't rated yet.
+func currentRPI(rating *schedule.ProjectedRating) string {
+	if math.IsNaN(rating.CurrentRPI) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.4f", rating.CurrentRPI)
+}
+
+func writeProjection(w io.Writer, projection *schedule.Projection, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RANK\tTEAM\tRECORD\tRPI\tCURRENT RPI\tCHANGE\tREMAINING")
+
+	for i, rating := range projection.Ratings {
+		if top > 0 && i >= top {
+			break
+		}
+
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%d-%d-%d\t%.4f\t%s\t%s\t%d\n",
+			i+1, rating.Team, rating.Wins, rating.Losses, rating.Ties, rating.RPI,
+			currentRPI(rating), signed(rating.RPIChange(), 4), rating.Remaining)
+	}
+
+	return tw.Flush()
+}
+
+// writePredictions lists the result given to each fixture and whether it was supplied or predicted.
+func writePredictions(w io.Writer, projection *schedule.Projection) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DATE\tSCORE\tSOURCE\tID")
+
+	for _, prediction := range projection.Predictions {
+		source := "predicted"
+		if prediction.Supplied {
+			source = "supplied"
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			prediction.Match.Date.Format(dateLayout), prediction.Match.ToString(), source, prediction.Match.ID)
+	}
+
+	return tw.Flush()
+}
+
+// projectDescription is the detail printed by rpi project -h.
+const projectDescription = `Gives every scheduled and postponed fixture a result and prints each
+team's projected RPI at the end of the season beside its current RPI.
+A fixture is won 1-0 by the team with the higher current RPI, or drawn
+when neither is favored, unless -outcomes names a file giving its result
+with one <id> <home>-<away> [annotation] per line.`
+
+func runProject(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("project", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi project [options] <file>", projectDescription)
+	opts := registerLoadFlags(flags)
+	outcomesFileName := flags.String("outcomes", "", "an outcomes file giving the results of some fixtures")
+	top := flags.Int("top", 0, "only print the top N teams (0 prints every team)")
+	fixtures := flags.Bool("fixtures", false, "also print the result given to each fixture")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var outcomes schedule.Outcomes
+	if *outcomesFileName != "" {
+		var err error
+		if outcomes, err = loadOutcomes(*outcomesFileName); err != nil {
+			_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+			return 1
+		}
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	projection, err := s.Project(outcomes)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	projection.Ratings.SortByRPI()
+
+	if err = writeProjection(stdout, projection, *top); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if *fixtures {
+		_, _ = fmt.Fprintln(stdout)
+		if err = writePredictions(stdout, projection); err != nil {
+			_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
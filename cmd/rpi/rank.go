@@ -0,0 +1,228 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/report"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// ranking is a single row of the ranking table.  Rank is the position in the table, which is by
+// adjusted RPI when adjustments are applied.
+type ranking struct {
+	Rank int
+	*schedule.AdjustedRating
+
+	// Split is the team's conference and non-conference ratings when they are shown.
+	Split *schedule.SplitRating
+}
+
+func (r ranking) record() string {
+	return fmt.Sprintf("%d-%d-%d", r.Wins, r.Losses, r.Ties)
+}
+
+// computeRankings calculates every team's RPI and orders the teams from best to worst.  When a table
+// is given the bonus and penalty adjustments are applied and the teams are ordered by adjusted RPI.
+func computeRankings(s *schedule.Schedule, table schedule.AdjustmentTable) ([]ranking, error) {
+	ratings, err := s.CalculateAdjusted(table)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings.SortByAdjustedRPI()
+
+	rankings := make([]ranking, 0, len(ratings))
+	for i, rating := range ratings {
+		rankings = append(rankings, ranking{Rank: i + 1, AdjustedRating: rating})
+	}
+
+	return rankings, nil
+}
+
+// addSplits adds each team's conference and non-conference ratings to its ranking.
+func addSplits(s *schedule.Schedule, rankings []ranking) error {
+	splits, err := s.CalculateSplits()
+	if err != nil {
+		return err
+	}
+
+	for i := range rankings {
+		rankings[i].Split = splits.Find(rankings[i].Team)
+	}
+
+	return nil
+}
+
+func writeRankings(w io.Writer, rankings []ranking, adjusted, splits bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprint(tw, "RANK\tTEAM\tRECORD\tWP\tOWP\tOOWP\tRPI")
+	if adjusted {
+		_, _ = fmt.Fprint(tw, "\tBONUS\tPENALTY\tARPI")
+	}
+	if splits {
+		_, _ = fmt.Fprint(tw, "\tCONFERENCE\tCONF\tCONF RPI\tNC\tNC RPI")
+	}
+	_, _ = fmt.Fprintln(tw)
+
+	for _, r := range rankings {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%.4f\t%.4f\t%.4f\t%.4f",
+			r.Rank, r.Team, r.record(), r.WP, r.OWP, r.OOWP, r.RPI)
+
+		if adjusted {
+			_, _ = fmt.Fprintf(tw, "\t%.4f\t%.4f\t%.4f", r.Bonus, r.Penalty, r.AdjustedRPI)
+		}
+
+		if splits && r.Split != nil {
+			_, _ = fmt.Fprintf(tw, "\t%s\t%s\t%s", conferenceName(r.Split.Conference), viewRecord(r.Split.InConference), viewRPI(r.Split.InConference))
+			_, _ = fmt.Fprintf(tw, "\t%s\t%s", viewRecord(r.Split.NonConference), viewRPI(r.Split.NonConference))
+		}
+
+		_, _ = fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
+
+func conferenceName(conference string) string {
+	if conference == "" {
+		return "-"
+	}
+
+	return conference
+}
+
+// viewRecord returns the record of a rating over part of a schedule, or - when no matches were played.
+func viewRecord(rating *schedule.Rating) string {
+	if rating == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d-%d-%d", rating.Wins, rating.Losses, rating.Ties)
+}
+
+// viewRPI returns the RPI of a rating over part of a schedule, or - when no matches were played.
+func viewRPI(rating *schedule.Rating) string {
+	if rating == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.4f", rating.RPI)
+}
+
+// writeReport renders the rankings with the report package in the named format.
+func writeReport(w io.Writer, rankings []ranking, adjusted, splits bool, format report.Format, opts report.Options) error {
+	r := &report.Report{Adjusted: adjusted, Splits: splits}
+	for _, ranking := range rankings {
+		r.Rows = append(r.Rows, report.Row{Rank: ranking.Rank, AdjustedRating: ranking.AdjustedRating, Split: ranking.Split})
+	}
+
+	return report.Render(w, r, format, opts)
+}
+
+// adjustmentTable returns the women's soccer bonus and penalty table when adjustments are requested.
+func adjustmentTable(adjusted bool) schedule.AdjustmentTable {
+	if adjusted {
+		return schedule.WomensSoccerAdjustments
+	}
+
+	return nil
+}
+
+// rankingWriter writes a ranking table in a single output format.
+type rankingWriter func(w io.Writer, rankings []ranking) error
+
+// newRankingWriter returns the writer for the named format.  The text format is the aligned table
+// written by writeRankings and every other format is rendered by the report package.
+func newRankingWriter(formatName, columnNames string, precision int, adjusted, splits bool) (rankingWriter, error) {
+	if formatName == "text" {
+		return func(w io.Writer, rankings []ranking) error {
+			return writeRankings(w, rankings, adjusted, splits)
+		}, nil
+	}
+
+	format, err := report.ParseFormat(formatName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := report.Options{Precision: precision}
+	if columnNames != "" {
+		if opts.Columns, err = report.ParseColumns(columnNames); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(w io.Writer, rankings []ranking) error {
+		return writeReport(w, rankings, adjusted, splits, format, opts)
+	}, nil
+}
+
+// rankDescription is the detail printed by rpi rank -h.
+const rankDescription = `Prints the RPI ranking of every team.  -adjusted applies the women's
+soccer bonus and penalty adjustments, and -conferences shows each team's
+conference and non-conference records and RPI beside its overall RPI.
+Those views keep the team's matches in the view but take its opponents'
+winning percentages from the whole schedule.  -format, -columns and
+-precision render the ranking for other tools.`
+
+func runRank(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("rank", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi rank [options] <file>", rankDescription)
+	opts := registerLoadFlags(flags)
+	top := flags.Int("top", 0, "only print the top N teams (0 prints every team)")
+	adjusted := flags.Bool("adjusted", false, "apply the women's soccer bonus and penalty adjustments")
+	conferences := flags.Bool("conferences", false, "add each team's conference and non-conference RPI; requires -teams")
+	formatName := flags.String("format", "text", "the output format: text, csv, json, markdown or html")
+	columnNames := flags.String("columns", "", "the columns of a csv, json, markdown or html ranking, such as rank,team,rpi")
+	precision := flags.Int("precision", report.DefaultPrecision, "the decimal places of a csv, json, markdown or html ranking")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	write, err := newRankingWriter(*formatName, *columnNames, *precision, *adjusted, *conferences)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	rankings, err := computeRankings(s, adjustmentTable(*adjusted))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if *conferences {
+		if err = addSplits(s, rankings); err != nil {
+			_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+			return 1
+		}
+	}
+
+	if *top > 0 && *top < len(rankings) {
+		rankings = rankings[:*top]
+	}
+
+	if err = write(stdout, rankings); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/document"
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// loadScenario reads a scenario file in the format named by its extension.  When the schedule has a
+// registry the names of the hypothetical matches' teams are resolved through it.
+func loadScenario(fileName string, s *schedule.Schedule) (*schedule.Scenario, error) {
+	format, err := document.FormatOf(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stored, err := document.DecodeScenario(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	scenario, err := stored.Scenario()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	if registry := s.GetRegistry(); registry != nil {
+		for i, m := range scenario.Matches {
+			if err = importer.ResolveTeams(m, registry); err != nil {
+				return nil, fmt.Errorf("%s: match %d: %w", fileName, i+1, err)
+			}
+		}
+	}
+
+	return scenario, nil
+}
+
+// previousRPI returns a team's RPI before the scenario, or - when it wasn't rated or is undefined.
+func previousRPI(impact *schedule.Impact) string {
+	if impact.Before == nil || math.IsNaN(impact.PreviousRPI) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.4f", impact.PreviousRPI)
+}
+
+// previousRank returns a team's rank before the scenario, or - when it wasn't rated.
+func previousRank(impact *schedule.Impact) string {
+	if impact.Before == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(impact.PreviousRank)
+}
+
+func writeScenario(w io.Writer, result *schedule.ScenarioResult) error {
+	if result.Name != "" {
+		_, _ = fmt.Fprintf(w, "Scenario: %s\n", result.Name)
+	}
+
+	if len(result.Impacts) == 0 {
+		_, _ = fmt.Fprintln(w, "No team's rating or rank changes.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RANK\tTEAM\tRECORD\tRPI\tBEFORE RANK\tBEFORE RPI\tCHANGE\tRPI CHANGE")
+
+	for _, impact := range result.Impacts {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%d-%d-%d\t%.4f\t%s\t%s\t%s\t%s\n",
+			impact.Rank, impact.Team, impact.Wins, impact.Losses, impact.Ties, impact.RPI,
+			previousRank(impact), previousRPI(impact), rankChange(&impact.Standing), rpiChange(&impact.Standing, 4))
+	}
+
+	return tw.Flush()
+}
+
+// scenarioDescription is the detail printed by rpi scenario -h.
+const scenarioDescription = `Rates the schedule with the results in a YAML or JSON scenario file and
+prints every team whose rating or rank changes with its rank and RPI
+before and after.  The file may have a name, a results map from match id
+to score such as 2-1 (OT), and a list of hypothetical matches each with
+a date, home, away, score and neutral.`
+
+func runScenario(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("scenario", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi scenario [options] <file> <scenario>", scenarioDescription)
+	opts := registerLoadFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	scenario, err := loadScenario(flags.Arg(1), s)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	result, err := s.Evaluate(scenario)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	if err = writeScenario(stdout, result); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
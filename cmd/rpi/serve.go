@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/server"
+)
+
+// serve answers requests on the listener until it fails.  It is a variable so tests can stop short
+// of serving.
+var serve = func(listener net.Listener, handler http.Handler) error {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return srv.Serve(listener)
+}
+
+// serveDescription is the detail printed by rpi serve -h.
+const serveDescription = `Serves the schedule and its rankings as a JSON API, starting with an
+empty schedule when no file is given.`
+
+func runServe(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi serve [options] [file]", serveDescription)
+	opts := registerLoadFlags(flags)
+	addr := flags.String("addr", "localhost:8080", "the address to listen on")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var s *schedule.Schedule
+	var err error
+	if flags.NArg() == 1 {
+		s, err = loadSchedule(flags.Arg(0), opts, stderr)
+	} else {
+		s, err = newSchedule(opts)
+	}
+
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(stdout, "rpi: serving %d matches on http://%s\n", len(s.GetMatches()), listener.Addr())
+
+	if err = serve(listener, server.New(s)); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// parseCutoffs parses a comma-separated list of ranks such as "32,48".
+func parseCutoffs(value string) ([]int, error) {
+	var cutoffs []int
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		cutoff, err := strconv.Atoi(field)
+		if err != nil || cutoff < 1 {
+			return nil, fmt.Errorf("invalid cutoff <%s>", field)
+		}
+
+		cutoffs = append(cutoffs, cutoff)
+	}
+
+	return cutoffs, nil
+}
+
+// medianRPI returns a team's median RPI, or - when it is never defined.
+func medianRPI(team *schedule.TeamSimulation) string {
+	if math.IsNaN(team.MedianRPI) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.4f", team.MedianRPI)
+}
+
+func writeSimulation(w io.Writer, simulation *schedule.Simulation, cutoffs []int, top int) error {
+	_, _ = fmt.Fprintf(w, "%d seasons, %d fixtures, seed %d\n", simulation.Iterations, simulation.Fixtures, simulation.Seed)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprint(tw, "RANK\tTEAM\tMEAN RANK\tBEST\tWORST\tMEDIAN RPI")
+	for _, cutoff := range cutoffs {
+		_, _ = fmt.Fprintf(tw, "\tTOP %d", cutoff)
+	}
+	_, _ = fmt.Fprintln(tw)
+
+	for i, team := range simulation.Teams {
+		if top > 0 && i >= top {
+			break
+		}
+
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%.2f\t%d\t%d\t%s", i+1, team.Team, team.MeanRank, team.BestRank, team.WorstRank, medianRPI(team))
+		for _, cutoff := range cutoffs {
+			_, _ = fmt.Fprintf(tw, "\t%.1f%%", 100*team.Probability(cutoff))
+		}
+		_, _ = fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
+
+// simulateDescription is the detail printed by rpi simulate -h.
+const simulateDescription = `Plays out the fixtures -iterations times, ranks the teams at the end of
+each season and prints each team's average, best and worst rank, median
+RPI and chance of finishing at or above each of -cutoffs.  Each fixture
+is tied with the chance -tie and otherwise won by the team with the
+higher current RPI more often the further apart they are.  A seed is
+picked and printed when -seed isn't given.`
+
+func runSimulate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi simulate [options] <file>", simulateDescription)
+	opts := registerLoadFlags(flags)
+	iterations := flags.Int("iterations", 1000, "the number of seasons to simulate")
+	seed := flags.Int64("seed", 0, "the seed of the random results (0 picks one, which is printed)")
+	workers := flags.Int("workers", 0, "the number of seasons to simulate at once (0 uses every CPU)")
+	cutoffNames := flags.String("cutoffs", "48", "the ranks to report the chance of finishing at or above, such as 32,48")
+	tie := flags.Float64("tie", schedule.DefaultModel.Tie, "the chance that a fixture is tied, from 0 to 1")
+	top := flags.Int("top", 0, "only print the top N teams (0 prints every team)")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	cutoffs, err := parseCutoffs(*cutoffNames)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 2
+	}
+
+	if *tie < 0 || *tie > 1 {
+		_, _ = fmt.Fprintf(stderr, "rpi: invalid tie chance <%v>\n", *tie)
+		return 2
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	model := schedule.DefaultModel
+	model.Tie = *tie
+
+	simulation, err := s.Simulate(schedule.SimulationOptions{Iterations: *iterations, Seed: *seed, Workers: *workers, Model: model})
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	simulation.Teams.SortByRank()
+
+	if err = writeSimulation(stdout, simulation, cutoffs, *top); err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
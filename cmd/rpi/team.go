@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+func writeTeam(w io.Writer, s *schedule.Schedule, r ranking, teamCount int, adjusted bool) error {
+	splits, err := s.GetSplits(r.Team)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(tw, "Team:\t%s\n", r.Team)
+	_, _ = fmt.Fprintf(tw, "Rank:\t%d of %d\n", r.Rank, teamCount)
+	_, _ = fmt.Fprintf(tw, "Record:\t%s\n", splits.Overall().ToString())
+	if s.GetRegistry() != nil {
+		_, _ = fmt.Fprintf(tw, "Division I Record:\t%s\n", r.record())
+	}
+	_, _ = fmt.Fprintf(tw, "Home:\t%s\n", splits.Home.ToString())
+	_, _ = fmt.Fprintf(tw, "Away:\t%s\n", splits.Away.ToString())
+	_, _ = fmt.Fprintf(tw, "Neutral:\t%s\n", splits.Neutral.ToString())
+	_, _ = fmt.Fprintf(tw, "WP:\t%.4f\n", r.WP)
+	_, _ = fmt.Fprintf(tw, "OWP:\t%.4f\n", r.OWP)
+	_, _ = fmt.Fprintf(tw, "OOWP:\t%.4f\n", r.OOWP)
+	_, _ = fmt.Fprintf(tw, "RPI:\t%.4f\n", r.RPI)
+
+	if adjusted {
+		_, _ = fmt.Fprintf(tw, "Bonus:\t%.4f\n", r.Bonus)
+		_, _ = fmt.Fprintf(tw, "Penalty:\t%.4f\n", r.Penalty)
+		_, _ = fmt.Fprintf(tw, "Adjusted RPI:\t%.4f\n", r.AdjustedRPI)
+	}
+
+	_, _ = fmt.Fprintln(tw)
+
+	_, _ = fmt.Fprintln(tw, "DATE\tOPPONENT\tLOCATION\tRESULT\tSCORE\tID")
+	for _, m := range s.GetMatchesForTeam(r.Team) {
+		opponent, err := m.GetOpponent(r.Team)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.Date.Format("2006-01-02"), opponent, m.LocationOf(r.Team), m.ResultUnder(r.Team, s.GetFormula().Decisions), m.ToString(), m.ID)
+	}
+
+	return tw.Flush()
+}
+
+// teamDescription is the detail printed by rpi team -h.
+const teamDescription = `Prints the RPI breakdown for a single team and lists each of its
+matches with its id.  -adjusted applies the women's soccer bonus and
+penalty adjustments.`
+
+func runTeam(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("team", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi team [options] <file> <name>", teamDescription)
+	opts := registerLoadFlags(flags)
+	adjusted := flags.Bool("adjusted", false, "apply the women's soccer bonus and penalty adjustments")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	s, err := loadSchedule(flags.Arg(0), opts, stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	teamName := flags.Arg(1)
+	if registry := s.GetRegistry(); registry != nil {
+		if t, err := registry.Resolve(teamName); err == nil {
+			teamName = t.Name
+		}
+	}
+
+	if !s.Contains(teamName) {
+		_, _ = fmt.Fprintf(stderr, "rpi: no matches found for team %s\n", teamName)
+		return 1
+	}
+
+	if !s.IsEligible(teamName) {
+		_, _ = fmt.Fprintf(stderr, "rpi: team %s is not eligible for the RPI\n", teamName)
+		return 1
+	}
+
+	rankings, err := computeRankings(s, adjustmentTable(*adjusted))
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+
+	for _, r := range rankings {
+		if r.Team != teamName {
+			continue
+		}
+
+		if err = writeTeam(stdout, s, r, len(rankings), *adjusted); err != nil {
+			_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+			return 1
+		}
+
+		return 0
+	}
+
+	// An eligible team is only left unranked when none of its matches are rated, such as when every
+	// opponent is outside Division I.
+	_, _ = fmt.Fprintf(stderr, "rpi: team %s has no rated matches\n", teamName)
+	return 1
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/validation"
+)
+
+// validateDescription is the detail printed by rpi validate -h.
+const validateDescription = `Checks that every line of a results file parses.  Results files contain
+one match per line in the form
+date,home,homeScore,away,awayScore[,location[,venue[,city]]] where
+location is H for the home team's venue or N for a neutral site.  The
+away score may be followed by how the match was decided, such as 1 (OT),
+1 (4-3 PK) or 0 (FF).  Team names containing commas must be quoted.  A
+header row naming the columns (date, home, home score, away, away score,
+location, venue, city, state) lets them appear in any order.  A match
+with both scores blank is a fixture that hasn't been played.  Blank
+lines and lines starting with # are ignored.`
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	setUsage(flags, "rpi validate [options] <file>", validateDescription)
+	teamsFileName := flags.String("teams", "", "a teams file listing every team; unknown teams are errors")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var teams importer.TeamResolver
+	if *teamsFileName != "" {
+		registry, err := loadRegistry(*teamsFileName)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+			return 1
+		}
+
+		teams = registry
+	}
+
+	fileName := flags.Arg(0)
+	file, err := os.Open(fileName)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "rpi: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	matches, errs := readMatches(file, fileName, teams)
+
+	_, issues, _ := validation.Validate(matches, validation.PolicyReport)
+	for _, issue := range issues {
+		errs = append(errs, fmt.Errorf("%s: %w", fileName, issue))
+	}
+
+	for _, err = range errs {
+		_, _ = fmt.Fprintln(stderr, err)
+	}
+
+	if len(errs) > 0 {
+		_, _ = fmt.Fprintf(stdout, "%s: %d matches, %d errors\n", fileName, len(matches), len(errs))
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: %d matches, ok\n", fileName, len(matches))
+
+	return 0
+}
@@ -0,0 +1,135 @@
+package correction
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// Change records a correction that was applied to a schedule.
+type Change struct {
+	Correction
+
+	// Before is the match as it was and After is the match as it is now, which is nil when the
+	// match was vacated.
+	Before *match.Match
+	After  *match.Match
+}
+
+func (c Change) String() string {
+	switch c.Action {
+	case ActionVacate:
+		return fmt.Sprintf("line %d: vacated %s: %s", c.Line, c.ID, describe(c.Before))
+	case ActionReplace:
+		return fmt.Sprintf("line %d: replaced %s: %s -> %s", c.Line, c.ID, describe(c.Before), describe(c.After))
+	}
+
+	return fmt.Sprintf("line %d: corrected %s: %s -> %s", c.Line, c.ID, describe(c.Before), describe(c.After))
+}
+
+func describe(m *match.Match) string {
+	return fmt.Sprintf("%s %s", m.Date.Format("2006-01-02"), m.ToString())
+}
+
+// Audit is the trail of changes made by applying a set of corrections, in the order they were applied.
+type Audit []Change
+
+// Write writes each change on its own line.
+func (a Audit) Write(w io.Writer) error {
+	for _, change := range a {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply makes the corrections to the schedule in order and returns the audit trail of what changed.
+// The team names of replacement matches are resolved through the schedule's registry when it has one.
+// Every correction is checked first, so nothing is changed when one names a match that doesn't exist
+// or was vacated by an earlier correction, or a replacement names a team that isn't registered.
+func Apply(s *schedule.Schedule, corrections []Correction) (Audit, error) {
+	if err := check(s, corrections); err != nil {
+		return nil, err
+	}
+
+	corrections, err := resolve(s, corrections)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := make(Audit, 0, len(corrections))
+	for _, c := range corrections {
+		change, err := apply(s, c)
+		if err != nil {
+			return audit, fmt.Errorf("line %d: %w", c.Line, err)
+		}
+
+		audit = append(audit, change)
+	}
+
+	return audit, nil
+}
+
+func check(s *schedule.Schedule, corrections []Correction) error {
+	vacated := make(map[string]bool)
+	for _, c := range corrections {
+		if _, err := s.GetMatch(c.ID); err != nil || vacated[c.ID] {
+			return fmt.Errorf("line %d: no match with id %s", c.Line, c.ID)
+		}
+
+		if c.Action == ActionVacate {
+			vacated[c.ID] = true
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the corrections with the team names of each replacement resolved through the
+// schedule's registry.  The replacements are copied so that the corrections passed in are unchanged.
+func resolve(s *schedule.Schedule, corrections []Correction) ([]Correction, error) {
+	registry := s.GetRegistry()
+	if registry == nil {
+		return corrections, nil
+	}
+
+	resolved := make([]Correction, len(corrections))
+	for i, c := range corrections {
+		if c.Action == ActionReplace {
+			c.Match = c.Match.Clone()
+			if err := importer.ResolveTeams(c.Match, registry); err != nil {
+				return nil, fmt.Errorf("line %d: %w", c.Line, err)
+			}
+		}
+
+		resolved[i] = c
+	}
+
+	return resolved, nil
+}
+
+func apply(s *schedule.Schedule, c Correction) (Change, error) {
+	change := Change{Correction: c}
+
+	var err error
+	switch c.Action {
+	case ActionCorrect:
+		change.After, err = s.UpdateMatch(c.ID, func(m *match.Match) error {
+			change.Before = m.Clone()
+			return m.SetScore(c.Score)
+		})
+	case ActionVacate:
+		change.Before, err = s.RemoveMatchByID(c.ID)
+	case ActionReplace:
+		if change.Before, err = s.ReplaceMatch(c.ID, c.Match); err == nil {
+			change.After, err = s.GetMatch(c.ID)
+		}
+	}
+
+	return change, err
+}
@@ -0,0 +1,135 @@
+package correction
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Action is what a correction does to a match.
+type Action int
+
+const (
+	// ActionCorrect changes the score of a match.
+	ActionCorrect Action = iota
+	// ActionVacate removes a match from the schedule.
+	ActionVacate
+	// ActionReplace puts a new match in place of a match.
+	ActionReplace
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCorrect:
+		return "correct"
+	case ActionVacate:
+		return "vacate"
+	case ActionReplace:
+		return "replace"
+	}
+
+	return "unknown"
+}
+
+// Correction is a single change to a schedule's matches, read from a line of a corrections file.
+type Correction struct {
+	Line   int
+	Action Action
+	ID     string
+
+	// Score is the corrected score of an ActionCorrect, such as "2-1" or "1-1 (4-3 PK)".
+	Score string
+
+	// Match is the replacement of an ActionReplace.
+	Match *match.Match
+}
+
+// Parse reads a corrections file, which holds one correction per line in one of the forms
+//
+//	correct <id> <home>-<away> [annotation]
+//	vacate <id>
+//	replace <id> date,home,homeScore,away,awayScore[,location[,venue[,city]]]
+//
+// Blank lines and lines starting with # are ignored.
+func Parse(r io.Reader) ([]Correction, error) {
+	var corrections []Correction
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		c, err := parseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		c.Line = line
+		corrections = append(corrections, c)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return corrections, nil
+}
+
+func parseLine(text string) (Correction, error) {
+	name, rest, _ := strings.Cut(text, " ")
+	id, argument, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	argument = strings.TrimSpace(argument)
+
+	c := Correction{ID: id}
+	if id == "" {
+		return c, fmt.Errorf("%s requires a match id", name)
+	}
+
+	switch name {
+	case "correct":
+		c.Action = ActionCorrect
+		c.Score = argument
+		if argument == "" {
+			return c, fmt.Errorf("correct requires a score")
+		}
+		if err := new(match.Match).SetScore(argument); err != nil {
+			return c, err
+		}
+	case "vacate":
+		c.Action = ActionVacate
+		if argument != "" {
+			return c, fmt.Errorf("unexpected %q after the match id", argument)
+		}
+	case "replace":
+		c.Action = ActionReplace
+		m, err := parseMatch(argument)
+		if err != nil {
+			return c, err
+		}
+		c.Match = m
+	default:
+		return c, fmt.Errorf("unknown correction %s", name)
+	}
+
+	return c, nil
+}
+
+// parseMatch reads a replacement match in the same form as a line of a results file.
+func parseMatch(text string) (*match.Match, error) {
+	matches, errs := importer.ReadCSV(strings.NewReader(text), importer.Options{})
+	if len(errs) > 0 {
+		return nil, errs[0].Err
+	}
+
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("replace requires a match")
+	}
+
+	return matches[0], nil
+}
@@ -0,0 +1,13 @@
+package correction_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCorrection(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Correction Suite")
+}
@@ -0,0 +1,196 @@
+package correction_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/correction"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Correction", func() {
+	Describe("Parse", func() {
+		It("should read every kind of correction", func() {
+			// Arrange
+			input := strings.Join([]string{
+				"# corrections from the conference office",
+				"correct 2023-11-06-uconn-kansas 2-2 (4-3 PK)",
+				"",
+				"vacate 2023-11-10-uconn-duke",
+				"replace 2023-11-14-wisconsin-uconn 2023-11-15,Wisconsin,71,UConn,72,N,Fiserv Forum",
+			}, "\n")
+
+			// Act
+			corrections, err := correction.Parse(strings.NewReader(input))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(corrections).To(HaveLen(3))
+
+			Expect(corrections[0]).To(Equal(correction.Correction{
+				Line: 2, Action: correction.ActionCorrect, ID: "2023-11-06-uconn-kansas", Score: "2-2 (4-3 PK)",
+			}))
+			Expect(corrections[1]).To(Equal(correction.Correction{
+				Line: 4, Action: correction.ActionVacate, ID: "2023-11-10-uconn-duke",
+			}))
+			Expect(corrections[2].Action).To(Equal(correction.ActionReplace))
+			Expect(corrections[2].Match.ToString()).To(Equal("Wisconsin,71,UConn,72"))
+			Expect(corrections[2].Match.Venue).To(Equal("Fiserv Forum"))
+		})
+
+		DescribeTable("should report the line that can't be parsed",
+			func(line, message string) {
+				// Act
+				corrections, err := correction.Parse(strings.NewReader("vacate a\n" + line + "\n"))
+
+				// Assert
+				Expect(corrections).To(BeNil())
+				Expect(err).To(MatchError("line 2: " + message))
+			},
+			Entry("unknown correction", "forfeit a", "unknown correction forfeit"),
+			Entry("missing id", "vacate", "vacate requires a match id"),
+			Entry("missing score", "correct a", "correct requires a score"),
+			Entry("invalid score", "correct a 2:1", "invalid score <2:1>"),
+			Entry("invalid decision", "correct a 2-1 (PK)", "a shootout requires a level score"),
+			Entry("vacate with a score", "vacate a 2-1", `unexpected "2-1" after the match id`),
+			Entry("invalid match", "replace a 2023-11-15,Wisconsin,seventy,UConn,72", "invalid home score <seventy>"),
+			Entry("missing match", "replace a", "replace requires a match"),
+		)
+	})
+
+	Describe("Apply", func() {
+		var pSchedule *schedule.Schedule
+
+		BeforeEach(func() {
+			pSchedule = schedule.NewSchedule()
+			Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")).To(Succeed())
+			Expect(pSchedule.AddMatchFromString("2023-11-10,UConn,82,Duke,68")).To(Succeed())
+			Expect(pSchedule.AddMatchFromString("2023-11-14,Wisconsin,71,UConn,72")).To(Succeed())
+		})
+
+		parse := func(lines ...string) []correction.Correction {
+			corrections, err := correction.Parse(strings.NewReader(strings.Join(lines, "\n")))
+			Expect(err).NotTo(HaveOccurred())
+			return corrections
+		}
+
+		It("should apply the corrections and record what changed", func() {
+			// Arrange
+			corrections := parse(
+				"correct 2023-11-06-uconn-kansas 64-65 (OT)",
+				"vacate 2023-11-10-uconn-duke",
+				"replace 2023-11-14-wisconsin-uconn 2023-11-15,Wisconsin,73,UConn,72",
+			)
+
+			// Act
+			audit, err := correction.Apply(pSchedule, corrections)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pSchedule.GetRecord("UConn")).To(Equal(schedule.Record{Losses: 2}))
+			Expect(pSchedule.GetMatches()[1].ID).To(Equal("2023-11-14-wisconsin-uconn"))
+
+			var buffer bytes.Buffer
+			Expect(audit.Write(&buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal(strings.Join([]string{
+				"line 1: corrected 2023-11-06-uconn-kansas: 2023-11-06 UConn,64,Kansas,57 -> 2023-11-06 UConn,64,Kansas,65 (OT)",
+				"line 2: vacated 2023-11-10-uconn-duke: 2023-11-10 UConn,82,Duke,68",
+				"line 3: replaced 2023-11-14-wisconsin-uconn: 2023-11-14 Wisconsin,71,UConn,72 -> 2023-11-15 Wisconsin,73,UConn,72",
+			}, "\n") + "\n"))
+		})
+
+		It("should keep the original match in the audit trail", func() {
+			// Arrange
+			original, err := pSchedule.GetMatch("2023-11-06-uconn-kansas")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			audit, err := correction.Apply(pSchedule, parse("correct 2023-11-06-uconn-kansas 1-0"))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(audit[0].Before.ToString()).To(Equal(original.ToString()))
+			Expect(audit[0].After).To(BeIdenticalTo(pSchedule.GetMatches()[0]))
+		})
+
+		DescribeTable("should change nothing when a correction names a missing match",
+			func(lines []string, message string) {
+				// Act
+				audit, err := correction.Apply(pSchedule, parse(lines...))
+
+				// Assert
+				Expect(audit).To(BeNil())
+				Expect(err).To(MatchError(message))
+				Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(3))
+				Expect(pSchedule.GetMatches()[0].Away.Score).To(Equal(57))
+			},
+			Entry("unknown id", []string{
+				"correct 2023-11-06-uconn-kansas 64-65",
+				"vacate 2023-11-11-uconn-duke",
+			}, "line 2: no match with id 2023-11-11-uconn-duke"),
+			Entry("vacated id", []string{
+				"vacate 2023-11-06-uconn-kansas",
+				"correct 2023-11-06-uconn-kansas 64-65",
+			}, "line 2: no match with id 2023-11-06-uconn-kansas"),
+		)
+
+		Describe("with a registry", func() {
+			BeforeEach(func() {
+				registry := team.NewRegistry()
+				Expect(registry.Register(&team.Team{Name: "UConn", Aliases: []string{"Connecticut"}})).To(Succeed())
+				for _, name := range []string{"Kansas", "Duke", "Wisconsin"} {
+					Expect(registry.Register(&team.Team{Name: name})).To(Succeed())
+				}
+				pSchedule.SetRegistry(registry)
+			})
+
+			It("should resolve the replacement's team names", func() {
+				// Arrange
+				corrections := parse("replace 2023-11-06-uconn-kansas 2023-11-06,Connecticut,1,Kansas,0")
+
+				// Act
+				_, err := correction.Apply(pSchedule, corrections)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pSchedule.GetMatches()[0].Home.Name).To(Equal("UConn"))
+				Expect(pSchedule.GetTeams()).To(ConsistOf("UConn", "Kansas", "Duke", "Wisconsin"))
+				Expect(corrections[0].Match.Home.Name).To(Equal("Connecticut"))
+			})
+
+			It("should change nothing when a replacement names an unknown team", func() {
+				// Arrange
+				corrections := parse(
+					"vacate 2023-11-10-uconn-duke",
+					"replace 2023-11-06-uconn-kansas 2023-11-06,UNC,1,Kansas,0",
+				)
+
+				// Act
+				audit, err := correction.Apply(pSchedule, corrections)
+
+				// Assert
+				Expect(audit).To(BeNil())
+				Expect(err).To(MatchError("line 2: unknown team UNC"))
+				Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(3))
+			})
+		})
+
+		It("should not share the replacement with the correction", func() {
+			// Arrange
+			corrections := parse("replace 2023-11-06-uconn-kansas 2023-11-06,UConn,1,Kansas,0")
+
+			// Act
+			_, err := correction.Apply(pSchedule, corrections)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(corrections[0].Match.ID).To(BeEmpty())
+			Expect(pSchedule.GetMatches()[0]).NotTo(BeIdenticalTo(corrections[0].Match))
+			Expect(pSchedule.GetMatches()[0].Decision).To(Equal(match.DecisionRegulation))
+		})
+	})
+})
@@ -0,0 +1,137 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an encoding of a document.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	}
+
+	return "unknown"
+}
+
+// ParseFormat parses a format name such as "json" or "yaml".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	}
+
+	return FormatJSON, fmt.Errorf("unknown format %s", name)
+}
+
+// FormatOf returns the format of a file from its extension.
+func FormatOf(fileName string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(fileName), "."))
+}
+
+// Encode writes the document in the specified format.
+func Encode(w io.Writer, doc *Document, format Format) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	return fmt.Errorf("unknown format %d", format)
+}
+
+// Decode reads a document in the specified format and upgrades it to the current version.
+func Decode(r io.Reader, format Format) (*Document, error) {
+	var doc Document
+
+	if err := decode(r, format, &doc); err != nil {
+		return nil, err
+	}
+
+	if err := doc.upgrade(); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// decode reads a value in the specified format, rejecting fields the value doesn't have.
+func decode(r io.Reader, format Format, v any) error {
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(r)
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	case FormatYAML:
+		decoder := yaml.NewDecoder(r)
+		decoder.KnownFields(true)
+		return decoder.Decode(v)
+	}
+
+	return fmt.Errorf("unknown format %d", format)
+}
+
+// upgrade converts a document written with an earlier version to the current version.  Each version
+// adds a case that converts from the version before it.
+func (d *Document) upgrade() error {
+	switch {
+	case d.Version == 0:
+		return fmt.Errorf("the document has no version")
+	case d.Version > CurrentVersion:
+		return fmt.Errorf("unsupported document version %d", d.Version)
+	}
+
+	if d.Version == 1 {
+		if err := d.upgradeFromVersion1(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upgradeFromVersion1 converts a version 1 document, which has none of the fields version 2 added.
+// Every match in it was played, and its matches are given IDs when they are added to a schedule.
+func (d *Document) upgradeFromVersion1() error {
+	for i, m := range d.Matches {
+		if m.ID != "" || m.State != match.StateCompleted || m.Overtimes != 0 {
+			return fmt.Errorf("match %d: a version 1 document can't have an id, a state or overtimes", i+1)
+		}
+	}
+
+	for _, t := range d.Teams {
+		if t.ID != "" || len(t.Aliases) > 0 || t.Conference != "" || t.Region != "" {
+			return fmt.Errorf("team %s: a version 1 document can't have an id, aliases, a conference or a region", t.Name)
+		}
+	}
+
+	d.Version = 2
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package document
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+)
+
+// CurrentVersion is the version of the documents written by this package.  Documents written with
+// an earlier version are upgraded when they are decoded.
+//
+// Version 2 added match IDs and states and team IDs, aliases, conferences and regions.
+const CurrentVersion = 2
+
+// Document is the versioned top-level form of a schedule, its teams and its computed ratings.
+type Document struct {
+	Version int      `json:"version" yaml:"version"`
+	Formula *Formula `json:"formula,omitempty" yaml:"formula,omitempty"`
+	Teams   []Team   `json:"teams,omitempty" yaml:"teams,omitempty"`
+	Matches []Match  `json:"matches" yaml:"matches"`
+	Ratings []Rating `json:"ratings,omitempty" yaml:"ratings,omitempty"`
+}
+
+// Side is one team's part in a match.
+type Side struct {
+	Name     string `json:"name" yaml:"name"`
+	Score    int    `json:"score" yaml:"score"`
+	Shootout int    `json:"shootout,omitempty" yaml:"shootout,omitempty"`
+}
+
+// Match is the stored form of a match.
+type Match struct {
+	ID        string         `json:"id,omitempty" yaml:"id,omitempty"`
+	Date      time.Time      `json:"date" yaml:"date"`
+	Home      Side           `json:"home" yaml:"home"`
+	Away      Side           `json:"away" yaml:"away"`
+	Neutral   bool           `json:"neutral,omitempty" yaml:"neutral,omitempty"`
+	Venue     string         `json:"venue,omitempty" yaml:"venue,omitempty"`
+	City      string         `json:"city,omitempty" yaml:"city,omitempty"`
+	Decision  match.Decision `json:"decision,omitempty" yaml:"decision,omitempty"`
+	Overtimes int            `json:"overtimes,omitempty" yaml:"overtimes,omitempty"`
+	State     match.State    `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// NewMatch returns the stored form of a match.
+func NewMatch(m *match.Match) Match {
+	return Match{
+		ID:        m.ID,
+		Date:      m.Date,
+		Home:      Side{Name: m.Home.Name, Score: m.Home.Score, Shootout: m.Home.Shootout},
+		Away:      Side{Name: m.Away.Name, Score: m.Away.Score, Shootout: m.Away.Shootout},
+		Neutral:   m.Neutral,
+		Venue:     m.Venue,
+		City:      m.City,
+		Decision:  m.Decision,
+		Overtimes: m.Overtimes,
+		State:     m.State,
+	}
+}
+
+// Match returns the match the stored form describes.
+func (m Match) Match() *match.Match {
+	return &match.Match{
+		ID:        m.ID,
+		Date:      m.Date,
+		Home:      match.Status{Name: m.Home.Name, Score: m.Home.Score, Shootout: m.Home.Shootout},
+		Away:      match.Status{Name: m.Away.Name, Score: m.Away.Score, Shootout: m.Away.Shootout},
+		Site:      match.Site{Neutral: m.Neutral, Venue: m.Venue, City: m.City},
+		Decision:  m.Decision,
+		Overtimes: m.Overtimes,
+		State:     m.State,
+	}
+}
+
+// Team is the stored form of a team.
+type Team struct {
+	ID         string        `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       string        `json:"name" yaml:"name"`
+	Aliases    []string      `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Conference string        `json:"conference,omitempty" yaml:"conference,omitempty"`
+	Division   team.Division `json:"division,omitempty" yaml:"division,omitempty"`
+	Region     string        `json:"region,omitempty" yaml:"region,omitempty"`
+	Wins       int           `json:"wins,omitempty" yaml:"wins,omitempty"`
+	Losses     int           `json:"losses,omitempty" yaml:"losses,omitempty"`
+	Ties       int           `json:"ties,omitempty" yaml:"ties,omitempty"`
+}
+
+// NewTeam returns the stored form of a team.
+func NewTeam(t *team.Team) Team {
+	return Team{
+		ID:         t.ID,
+		Name:       t.Name,
+		Aliases:    slices.Clone(t.Aliases),
+		Conference: t.Conference,
+		Division:   t.Division,
+		Region:     t.Region,
+		Wins:       t.Wins,
+		Losses:     t.Losses,
+		Ties:       t.Ties,
+	}
+}
+
+// Team returns the team the stored form describes.
+func (t Team) Team() *team.Team {
+	return &team.Team{
+		ID:         t.ID,
+		Name:       t.Name,
+		Aliases:    slices.Clone(t.Aliases),
+		Conference: t.Conference,
+		Division:   t.Division,
+		Region:     t.Region,
+		Wins:       t.Wins,
+		Losses:     t.Losses,
+		Ties:       t.Ties,
+	}
+}
+
+// FromSchedule returns a document holding the schedule's matches, formula and teams.
+func FromSchedule(s *schedule.Schedule) *Document {
+	formula := NewFormula(s.GetFormula())
+	matches := s.GetMatches()
+
+	doc := &Document{
+		Version: CurrentVersion,
+		Formula: &formula,
+		Matches: make([]Match, 0, len(matches)),
+	}
+
+	for _, m := range matches {
+		doc.Matches = append(doc.Matches, NewMatch(m))
+	}
+
+	if registry := s.GetRegistry(); registry != nil {
+		for _, name := range registry.Names() {
+			doc.Teams = append(doc.Teams, NewTeam(registry.Get(name)))
+		}
+	}
+
+	return doc
+}
+
+// Schedule returns a schedule holding the document's matches, using its formula and teams.
+func (d *Document) Schedule() (*schedule.Schedule, error) {
+	s := schedule.NewSchedule()
+
+	if d.Formula != nil {
+		if err := s.SetFormula(d.Formula.Formula()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(d.Teams) > 0 {
+		registry := team.NewRegistry()
+		for _, t := range d.Teams {
+			if err := registry.Register(t.Team()); err != nil {
+				return nil, err
+			}
+		}
+
+		s.SetRegistry(registry)
+	}
+
+	for i, m := range d.Matches {
+		if err := s.AddMatch(m.Match()); err != nil {
+			return nil, fmt.Errorf("match %d: %w", i+1, err)
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,13 @@
+package document_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDocument(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Document Suite")
+}
@@ -0,0 +1,213 @@
+package document_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/document"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Document", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+		Expect(pSchedule.SetFormula(schedule.BasketballFormula)).To(Succeed())
+
+		eastern := time.FixedZone("EST", -5*60*60)
+		Expect(pSchedule.AddMatch(&match.Match{
+			Date: time.Date(2023, 11, 6, 19, 30, 0, 0, eastern),
+			Home: match.Status{Name: "UConn", Score: 64},
+			Away: match.Status{Name: "Kansas", Score: 57},
+		})).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-11-10,Duke,1,UConn,1 (4-3 PK),N,Madison Square Garden,New York")).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-11-14,Kansas,70,Duke,68 (2OT)")).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-11-18,Emory,50,Duke,90")).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-12-02,UConn,,Duke,")).To(Succeed())
+
+		registry := team.NewRegistry()
+		for _, name := range []string{"UConn", "Kansas", "Duke"} {
+			t := team.NewTeam(name)
+			t.Division = team.DivisionI
+			t.Conference = "Big East"
+			Expect(registry.Register(t)).To(Succeed())
+		}
+		emory := team.NewTeam("Emory")
+		emory.Division = team.DivisionIII
+		Expect(registry.Register(emory)).To(Succeed())
+		pSchedule.SetRegistry(registry)
+	})
+
+	roundTrip := func(doc *document.Document, format document.Format) *document.Document {
+		var buffer bytes.Buffer
+		Expect(document.Encode(&buffer, doc, format)).To(Succeed())
+
+		decoded, err := document.Decode(&buffer, format)
+		Expect(err).NotTo(HaveOccurred())
+
+		return decoded
+	}
+
+	for _, format := range []document.Format{document.FormatJSON, document.FormatYAML} {
+		format := format
+
+		Context(format.String(), func() {
+			It("should round-trip a schedule", func() {
+				// Arrange
+				doc := document.FromSchedule(pSchedule)
+
+				// Act
+				decoded := roundTrip(doc, format)
+				s, err := decoded.Schedule()
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded.Formula).To(Equal(doc.Formula))
+				Expect(decoded.Teams).To(Equal(doc.Teams))
+				Expect(s.GetFormula()).To(Equal(pSchedule.GetFormula()))
+				Expect(s.GetRegistry().Division("Emory")).To(Equal(team.DivisionIII))
+				Expect(s.GetRegistry().Conference("Duke")).To(Equal("Big East"))
+				Expect(s.GetRegistry().Get("duke").ID).To(Equal("duke"))
+				Expect(s.GetMatches()).To(HaveLen(5))
+
+				for i, m := range s.GetMatches() {
+					original := pSchedule.GetMatches()[i]
+					Expect(m.Date.Equal(original.Date)).To(BeTrue())
+					_, offset := m.Date.Zone()
+					_, originalOffset := original.Date.Zone()
+					Expect(offset).To(Equal(originalOffset))
+					Expect(m.ID).To(Equal(original.ID))
+					Expect(m.Home).To(Equal(original.Home))
+					Expect(m.Away).To(Equal(original.Away))
+					Expect(m.Site).To(Equal(original.Site))
+					Expect(m.Decision).To(Equal(original.Decision))
+					Expect(m.Overtimes).To(Equal(original.Overtimes))
+					Expect(m.State).To(Equal(original.State))
+				}
+
+				Expect(s.GetMatches()[2].ToString()).To(Equal("Kansas,70,Duke,68 (2OT)"))
+				Expect(s.GetMatches()[4].State).To(Equal(match.StateScheduled))
+			})
+
+			It("should round-trip exact ratings", func() {
+				// Arrange
+				ratings, err := pSchedule.CalculateAllExact()
+				Expect(err).NotTo(HaveOccurred())
+
+				doc := document.FromSchedule(pSchedule)
+				doc.Ratings = document.NewRatings(ratings)
+
+				// Act
+				decoded, err := roundTrip(doc, format).ExactRatings()
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(HaveLen(len(ratings)))
+				for i, rating := range ratings {
+					Expect(decoded[i].Team).To(Equal(rating.Team))
+					Expect(decoded[i].RPI.Cmp(rating.RPI)).To(Equal(0))
+					Expect(decoded[i].OOWP.Fraction()).To(Equal(rating.OOWP.Fraction()))
+				}
+			})
+		})
+	}
+
+	It("should write stable names", func() {
+		// Arrange
+		var buffer bytes.Buffer
+
+		// Act
+		Expect(document.Encode(&buffer, document.FromSchedule(pSchedule), document.FormatJSON)).To(Succeed())
+
+		// Assert
+		json := buffer.String()
+		Expect(json).To(ContainSubstring(`"version": 2`))
+		Expect(json).To(ContainSubstring(`"date": "2023-11-06T19:30:00-05:00"`))
+		Expect(json).To(ContainSubstring(`"decision": "shootout"`))
+		Expect(json).To(ContainSubstring(`"shootout": 4`))
+		Expect(json).To(ContainSubstring(`"division": "III"`))
+		Expect(json).To(ContainSubstring(`"shootout": "tie"`))
+		Expect(json).To(ContainSubstring(`"state": "scheduled"`))
+		Expect(strings.Count(json, `"state"`)).To(Equal(1))
+	})
+
+	It("should encode an undefined element without a value", func() {
+		// Arrange
+		var buffer bytes.Buffer
+		doc := &document.Document{
+			Version: document.CurrentVersion,
+			Ratings: document.NewRatings(schedule.ExactRatings{{Team: "Duke"}}),
+		}
+
+		// Act
+		Expect(document.Encode(&buffer, doc, document.FormatJSON)).To(Succeed())
+		decoded, err := document.Decode(&buffer, document.FormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+		ratings, err := decoded.ExactRatings()
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ratings[0].RPI.IsDefined()).To(BeFalse())
+	})
+
+	It("should upgrade a version 1 document", func() {
+		// Arrange
+		input := `version: 1
+teams:
+  - name: Duke
+    division: I
+matches:
+  - date: 2023-11-10T00:00:00Z
+    home: {name: UConn, score: 82}
+    away: {name: Duke, score: 68}
+`
+
+		// Act
+		decoded, err := document.Decode(strings.NewReader(input), document.FormatYAML)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.Version).To(Equal(document.CurrentVersion))
+
+		s, err := decoded.Schedule()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.GetTotalMatchesPlayed()).To(Equal(1))
+		Expect(s.GetMatch("2023-11-10-uconn-duke")).To(HaveField("State", match.StateCompleted))
+	})
+
+	DescribeTable("should reject a document it can't read",
+		func(input string, format document.Format, message string) {
+			// Act
+			_, err := document.Decode(strings.NewReader(input), format)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("no version", `{"matches": []}`, document.FormatJSON, "the document has no version"),
+		Entry("a newer version", "version: 99\nmatches: []\n", document.FormatYAML, "unsupported document version 99"),
+		Entry("an unknown field", `{"version": 2, "matchez": []}`, document.FormatJSON, "unknown field"),
+		Entry("an unknown decision", `{"version": 2, "matches": [{"decision": "coin-toss"}]}`, document.FormatJSON, "unknown decision <coin-toss>"),
+		Entry("a version 1 match with an id", `{"version": 1, "matches": [{"id": "x"}]}`, document.FormatJSON,
+			"match 1: a version 1 document can't have an id, a state or overtimes"),
+		Entry("a version 1 team with a conference", `{"version": 1, "teams": [{"name": "Duke", "conference": "ACC"}], "matches": []}`,
+			document.FormatJSON, "team Duke: a version 1 document can't have an id, aliases, a conference or a region"),
+	)
+
+	Describe("FormatOf", func() {
+		It("should choose the format from the extension", func() {
+			Expect(document.FormatOf("season.json")).To(Equal(document.FormatJSON))
+			Expect(document.FormatOf("season.yml")).To(Equal(document.FormatYAML))
+			Expect(document.FormatOf("season.yaml")).To(Equal(document.FormatYAML))
+
+			_, err := document.FormatOf("season.csv")
+			Expect(err).To(MatchError("unknown format csv"))
+		})
+	})
+})
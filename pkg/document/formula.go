@@ -0,0 +1,91 @@
+package document
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// Weighting is the stored form of a location weighting.
+type Weighting struct {
+	HomeWin     float64 `json:"homeWin" yaml:"homeWin"`
+	AwayWin     float64 `json:"awayWin" yaml:"awayWin"`
+	NeutralWin  float64 `json:"neutralWin" yaml:"neutralWin"`
+	HomeLoss    float64 `json:"homeLoss" yaml:"homeLoss"`
+	AwayLoss    float64 `json:"awayLoss" yaml:"awayLoss"`
+	NeutralLoss float64 `json:"neutralLoss" yaml:"neutralLoss"`
+	HomeTie     float64 `json:"homeTie" yaml:"homeTie"`
+	AwayTie     float64 `json:"awayTie" yaml:"awayTie"`
+	NeutralTie  float64 `json:"neutralTie" yaml:"neutralTie"`
+}
+
+// Formula is the stored form of a formula.
+type Formula struct {
+	Name            string                          `json:"name" yaml:"name"`
+	WPWeight        float64                         `json:"wpWeight" yaml:"wpWeight"`
+	OWPWeight       float64                         `json:"owpWeight" yaml:"owpWeight"`
+	OOWPWeight      float64                         `json:"oowpWeight" yaml:"oowpWeight"`
+	WinValue        float64                         `json:"winValue" yaml:"winValue"`
+	TieValue        float64                         `json:"tieValue" yaml:"tieValue"`
+	LossValue       float64                         `json:"lossValue" yaml:"lossValue"`
+	OWPExcludesTeam bool                            `json:"owpExcludesTeam" yaml:"owpExcludesTeam"`
+	WeightedWP      bool                            `json:"weightedWP" yaml:"weightedWP"`
+	Weighting       *Weighting                      `json:"weighting,omitempty" yaml:"weighting,omitempty"`
+	Decisions       map[match.Decision]match.Credit `json:"decisions,omitempty" yaml:"decisions,omitempty"`
+}
+
+// NewFormula returns the stored form of a formula.
+func NewFormula(f schedule.Formula) Formula {
+	formula := Formula{
+		Name:            f.Name,
+		WPWeight:        f.WPWeight,
+		OWPWeight:       f.OWPWeight,
+		OOWPWeight:      f.OOWPWeight,
+		WinValue:        f.WinValue,
+		TieValue:        f.TieValue,
+		LossValue:       f.LossValue,
+		OWPExcludesTeam: f.OWPExcludesTeam,
+		WeightedWP:      f.WeightedWP,
+	}
+
+	if f.Weighting != (schedule.Weighting{}) {
+		w := Weighting(f.Weighting)
+		formula.Weighting = &w
+	}
+
+	if len(f.Decisions) > 0 {
+		formula.Decisions = make(map[match.Decision]match.Credit, len(f.Decisions))
+		for decision, credit := range f.Decisions {
+			formula.Decisions[decision] = credit
+		}
+	}
+
+	return formula
+}
+
+// Formula returns the formula the stored form describes.
+func (f Formula) Formula() schedule.Formula {
+	formula := schedule.Formula{
+		Name:            f.Name,
+		WPWeight:        f.WPWeight,
+		OWPWeight:       f.OWPWeight,
+		OOWPWeight:      f.OOWPWeight,
+		WinValue:        f.WinValue,
+		TieValue:        f.TieValue,
+		LossValue:       f.LossValue,
+		OWPExcludesTeam: f.OWPExcludesTeam,
+		WeightedWP:      f.WeightedWP,
+	}
+
+	if f.Weighting != nil {
+		formula.Weighting = schedule.Weighting(*f.Weighting)
+	}
+
+	if len(f.Decisions) > 0 {
+		formula.Decisions = make(match.DecisionRules, len(f.Decisions))
+		for decision, credit := range f.Decisions {
+			formula.Decisions[decision] = credit
+		}
+	}
+
+	return formula
+}
@@ -0,0 +1,102 @@
+package document
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// Element is the stored form of an exact RPI element.  The fraction is the exact value and the value
+// is its nearest float64, which is omitted when the element is undefined.
+type Element struct {
+	Fraction string   `json:"fraction" yaml:"fraction"`
+	Value    *float64 `json:"value" yaml:"value"`
+}
+
+// NewElement returns the stored form of an element.
+func NewElement(e schedule.Element) Element {
+	if !e.IsDefined() {
+		return Element{Fraction: e.Fraction()}
+	}
+
+	value := e.Float()
+
+	return Element{Fraction: e.Fraction(), Value: &value}
+}
+
+// Element returns the element the stored form describes.
+func (e Element) Element() (schedule.Element, error) {
+	if e.Fraction == schedule.NewElement(nil).Fraction() {
+		return schedule.NewElement(nil), nil
+	}
+
+	r, ok := new(big.Rat).SetString(e.Fraction)
+	if !ok {
+		return schedule.Element{}, fmt.Errorf("invalid fraction <%s>", e.Fraction)
+	}
+
+	return schedule.NewElement(r), nil
+}
+
+// Rating is the stored form of a team's exact rating.
+type Rating struct {
+	Team   string  `json:"team" yaml:"team"`
+	Wins   int     `json:"wins" yaml:"wins"`
+	Losses int     `json:"losses" yaml:"losses"`
+	Ties   int     `json:"ties" yaml:"ties"`
+	WP     Element `json:"wp" yaml:"wp"`
+	OWP    Element `json:"owp" yaml:"owp"`
+	OOWP   Element `json:"oowp" yaml:"oowp"`
+	RPI    Element `json:"rpi" yaml:"rpi"`
+}
+
+// NewRatings returns the stored form of every rating.
+func NewRatings(ratings schedule.ExactRatings) []Rating {
+	stored := make([]Rating, 0, len(ratings))
+	for _, r := range ratings {
+		stored = append(stored, Rating{
+			Team:   r.Team,
+			Wins:   r.Wins,
+			Losses: r.Losses,
+			Ties:   r.Ties,
+			WP:     NewElement(r.WP),
+			OWP:    NewElement(r.OWP),
+			OOWP:   NewElement(r.OOWP),
+			RPI:    NewElement(r.RPI),
+		})
+	}
+
+	return stored
+}
+
+// ExactRatings returns the ratings the document holds.
+func (d *Document) ExactRatings() (schedule.ExactRatings, error) {
+	ratings := make(schedule.ExactRatings, 0, len(d.Ratings))
+	for _, r := range d.Ratings {
+		rating := &schedule.ExactRating{Team: r.Team, Wins: r.Wins, Losses: r.Losses, Ties: r.Ties}
+
+		elements := []struct {
+			stored Element
+			target *schedule.Element
+		}{
+			{r.WP, &rating.WP},
+			{r.OWP, &rating.OWP},
+			{r.OOWP, &rating.OOWP},
+			{r.RPI, &rating.RPI},
+		}
+
+		for _, element := range elements {
+			value, err := element.stored.Element()
+			if err != nil {
+				return nil, fmt.Errorf("rating for %s: %w", r.Team, err)
+			}
+
+			*element.target = value
+		}
+
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,100 @@
+package document
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// Scenario is the stored form of a what-if scenario, written to be simple enough to edit by hand:
+//
+//	name: Beat Duke, tie UNC
+//	results:
+//	  2023-10-05-duke-unc: 1-1
+//	matches:
+//	  - date: 2023-10-12
+//	    home: Duke
+//	    away: Virginia
+//	    score: 2-1 (OT)
+type Scenario struct {
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Results gives the result of matches in the schedule by ID, such as "2-1" or "1-1 (4-3 PK)".
+	Results map[string]string `json:"results,omitempty" yaml:"results,omitempty"`
+
+	Matches []ScenarioMatch `json:"matches,omitempty" yaml:"matches,omitempty"`
+}
+
+// ScenarioMatch is the stored form of a hypothetical match.
+type ScenarioMatch struct {
+	// Date is the day of the match in the form 2006-01-02.  Today is used when it is empty.
+	Date    string `json:"date,omitempty" yaml:"date,omitempty"`
+	Home    string `json:"home" yaml:"home"`
+	Away    string `json:"away" yaml:"away"`
+	Score   string `json:"score" yaml:"score"`
+	Neutral bool   `json:"neutral,omitempty" yaml:"neutral,omitempty"`
+}
+
+// DecodeScenario reads a scenario in the specified format.
+func DecodeScenario(r io.Reader, format Format) (*Scenario, error) {
+	var scenario Scenario
+
+	if err := decode(r, format, &scenario); err != nil {
+		return nil, err
+	}
+
+	return &scenario, nil
+}
+
+// Scenario returns the scenario the stored form describes.
+func (s *Scenario) Scenario() (*schedule.Scenario, error) {
+	scenario := &schedule.Scenario{Name: s.Name, Results: make(schedule.Outcomes, len(s.Results))}
+
+	for id, score := range s.Results {
+		if err := new(match.Match).SetScore(score); err != nil {
+			return nil, fmt.Errorf("result %s: %w", id, err)
+		}
+
+		scenario.Results[id] = score
+	}
+
+	for i, m := range s.Matches {
+		hypothetical, err := m.Match()
+		if err != nil {
+			return nil, fmt.Errorf("match %d: %w", i+1, err)
+		}
+
+		scenario.Matches = append(scenario.Matches, hypothetical)
+	}
+
+	return scenario, nil
+}
+
+// Match returns the hypothetical match the stored form describes.
+func (m ScenarioMatch) Match() (*match.Match, error) {
+	date := time.Now()
+	if m.Date != "" {
+		var err error
+		if date, err = time.Parse("2006-01-02", m.Date); err != nil {
+			return nil, fmt.Errorf("invalid date <%s>", m.Date)
+		}
+	}
+
+	if m.Score == "" {
+		return nil, fmt.Errorf("the score is empty")
+	}
+
+	hypothetical, err := match.NewMatchFromFields(date, m.Home, "", m.Away, "", match.Site{Neutral: m.Neutral})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = hypothetical.SetScore(m.Score); err != nil {
+		return nil, err
+	}
+
+	return hypothetical, nil
+}
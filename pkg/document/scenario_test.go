@@ -0,0 +1,84 @@
+package document_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/document"
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario", func() {
+	It("should read a scenario written by hand", func() {
+		// Arrange
+		input := `name: Beat Duke
+results:
+  2023-10-05-duke-unc: 1-1 (2OT)
+matches:
+  - date: 2023-10-12
+    home: Duke
+    away: Virginia
+    score: 2-1 (OT)
+    neutral: true
+`
+
+		// Act
+		stored, err := document.DecodeScenario(strings.NewReader(input), document.FormatYAML)
+		Expect(err).NotTo(HaveOccurred())
+
+		scenario, err := stored.Scenario()
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scenario.Name).To(Equal("Beat Duke"))
+		Expect(scenario.Results).To(HaveKeyWithValue("2023-10-05-duke-unc", "1-1 (2OT)"))
+		Expect(scenario.Matches).To(HaveLen(1))
+
+		hypothetical := scenario.Matches[0]
+		Expect(hypothetical.Date).To(Equal(time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)))
+		Expect(hypothetical.Home.Name).To(Equal("Duke"))
+		Expect(hypothetical.Away.Name).To(Equal("Virginia"))
+		Expect(hypothetical.Home.Score).To(Equal(2))
+		Expect(hypothetical.Away.Score).To(Equal(1))
+		Expect(hypothetical.Decision).To(Equal(match.DecisionOvertime))
+		Expect(hypothetical.Neutral).To(BeTrue())
+		Expect(hypothetical.IsPlayed()).To(BeTrue())
+	})
+
+	It("should read a scenario in JSON", func() {
+		// Arrange
+		input := `{"results": {"2023-10-05-duke-unc": "0-3"}}`
+
+		// Act
+		stored, err := document.DecodeScenario(strings.NewReader(input), document.FormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+
+		scenario, err := stored.Scenario()
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scenario.Results).To(HaveLen(1))
+		Expect(scenario.Matches).To(BeEmpty())
+	})
+
+	DescribeTable("should reject a scenario it can't use",
+		func(input string, message string) {
+			// Act
+			stored, err := document.DecodeScenario(strings.NewReader(input), document.FormatJSON)
+			if err == nil {
+				_, err = stored.Scenario()
+			}
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("an unknown field", `{"resultz": {}}`, "unknown field"),
+		Entry("an invalid result", `{"results": {"2023-10-05-duke-unc": "won"}}`, "result 2023-10-05-duke-unc: invalid score <won>"),
+		Entry("an invalid date", `{"matches": [{"date": "10/12/2023", "home": "A", "away": "B", "score": "1-0"}]}`, "match 1: invalid date <10/12/2023>"),
+		Entry("a match without a score", `{"matches": [{"home": "A", "away": "B"}]}`, "match 1: the score is empty"),
+		Entry("a match without a home team", `{"matches": [{"away": "B", "score": "1-0"}]}`, "match 1: the home team name is empty"),
+	)
+})
@@ -0,0 +1,361 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/team"
+)
+
+// Column is a field of a match in an input file.
+type Column int
+
+const (
+	ColumnDate Column = iota
+	ColumnHome
+	ColumnHomeScore
+	ColumnAway
+	ColumnAwayScore
+	ColumnLocation
+	ColumnVenue
+	ColumnCity
+	ColumnState
+)
+
+func (c Column) String() string {
+	switch c {
+	case ColumnDate:
+		return "date"
+	case ColumnHome:
+		return "home"
+	case ColumnHomeScore:
+		return "home score"
+	case ColumnAway:
+		return "away"
+	case ColumnAwayScore:
+		return "away score"
+	case ColumnLocation:
+		return "location"
+	case ColumnVenue:
+		return "venue"
+	case ColumnCity:
+		return "city"
+	case ColumnState:
+		return "state"
+	}
+
+	return "unknown"
+}
+
+// Columns maps each column to the header names it may appear under.  Header names are compared
+// without regard to case, spaces, underscores or hyphens.
+type Columns map[Column][]string
+
+// DefaultColumns is the header names recognized when no column mapping is given.
+var DefaultColumns = Columns{
+	ColumnDate:      {"date", "match date", "game date"},
+	ColumnHome:      {"home", "home team"},
+	ColumnHomeScore: {"home score", "home goals", "home points"},
+	ColumnAway:      {"away", "away team", "visitor", "visiting team"},
+	ColumnAwayScore: {"away score", "away goals", "away points", "visitor score"},
+	ColumnLocation:  {"location", "site"},
+	ColumnVenue:     {"venue", "stadium"},
+	ColumnCity:      {"city"},
+	ColumnState:     {"state", "status"},
+}
+
+// DefaultDateLayouts is the date layouts tried, in order, when no layouts are given.
+var DefaultDateLayouts = []string{
+	"2006-01-02",
+	"1/2/2006",
+	"01/02/2006",
+	"2006/01/02",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	time.RFC3339,
+}
+
+// positional is the column order of a file without a header row.
+var positional = []Column{ColumnDate, ColumnHome, ColumnHomeScore, ColumnAway, ColumnAwayScore, ColumnLocation, ColumnVenue, ColumnCity}
+
+// required is the columns every header row must name.
+var required = []Column{ColumnHome, ColumnHomeScore, ColumnAway, ColumnAwayScore}
+
+// Options controls how an input file is read.
+type Options struct {
+	// FileName is the name reported in errors.
+	FileName string
+
+	// Columns maps the columns to header names.  DefaultColumns is used when it is nil.
+	Columns Columns
+
+	// DateLayouts is the date layouts tried, in order.  DefaultDateLayouts is used when it is empty.
+	DateLayouts []string
+
+	// Comma is the field delimiter.  A comma is used when it is zero.
+	Comma rune
+
+	// Teams resolves each team name to its canonical name when it is not nil.  A record naming a team
+	// that can't be resolved is an error.
+	Teams TeamResolver
+}
+
+// TeamResolver finds the registered team for a reported name.  It is implemented by team.Registry,
+// which only accepts registered names, and team.Resolver, which also matches names that are close.
+type TeamResolver interface {
+	Resolve(name string) (*team.Team, error)
+}
+
+func (o Options) columns() Columns {
+	if o.Columns == nil {
+		return DefaultColumns
+	}
+
+	return o.Columns
+}
+
+func (o Options) dateLayouts() []string {
+	if len(o.DateLayouts) == 0 {
+		return DefaultDateLayouts
+	}
+
+	return o.DateLayouts
+}
+
+// ReadCSV reads every match in a CSV file.  The first record is a header row when it names the home,
+// home score, away and away score columns; otherwise records hold the columns
+// date,home,homeScore,away,awayScore[,location[,venue[,city]]] or the undated
+// home,homeScore,away,awayScore.  A header row must also name the date column, and nothing is read
+// from a file whose header doesn't.  A record with both scores blank is a scheduled fixture, and a
+// header row may name a state column holding completed, scheduled, postponed or cancelled.  Quoted
+// fields may contain commas, and blank lines and lines starting with # are ignored.  Records that
+// can't be parsed are skipped and reported with their line number.
+func ReadCSV(r io.Reader, opts Options) ([]*match.Match, Errors) {
+	var matches []*match.Match
+	var errs Errors
+
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	var header map[Column]int
+	first := true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseError *csv.ParseError
+			if !errors.As(err, &parseError) {
+				errs = append(errs, &LineError{FileName: opts.FileName, Err: err})
+				break
+			}
+
+			errs = append(errs, &LineError{FileName: opts.FileName, Line: parseError.Line, Err: parseError.Err})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if first {
+			first = false
+			if header = readHeader(record, opts.columns()); header != nil {
+				if _, ok := header[ColumnDate]; !ok {
+					errs = append(errs, &LineError{FileName: opts.FileName, Line: line, Err: fmt.Errorf("the header has no %s column", ColumnDate)})
+					return nil, errs
+				}
+
+				continue
+			}
+		}
+
+		m, err := parseRecord(record, header, opts.dateLayouts())
+		if err == nil && opts.Teams != nil {
+			err = ResolveTeams(m, opts.Teams)
+		}
+
+		if err != nil {
+			errs = append(errs, &LineError{FileName: opts.FileName, Line: line, Err: err})
+			continue
+		}
+
+		matches = append(matches, m)
+	}
+
+	return matches, errs
+}
+
+// readHeader returns the index of each column named in the record, or nil when the record isn't a
+// header row.
+func readHeader(record []string, columns Columns) map[Column]int {
+	index := make(map[Column]int)
+
+	for i, name := range record {
+		for column, names := range columns {
+			for _, candidate := range names {
+				if normalize(candidate) == normalize(name) {
+					index[column] = i
+				}
+			}
+		}
+	}
+
+	for _, column := range required {
+		if _, ok := index[column]; !ok {
+			return nil
+		}
+	}
+
+	return index
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// positionalIndex returns the column index of a record without a header row.
+func positionalIndex(record []string) (map[Column]int, error) {
+	columns := positional
+	switch {
+	case len(record) == 4:
+		columns = positional[1:5]
+	case len(record) < 5 || len(record) > len(positional):
+		return nil, fmt.Errorf("expected 4 to %d columns but found %d", len(positional), len(record))
+	}
+
+	index := make(map[Column]int, len(record))
+	for i := range record {
+		index[columns[i]] = i
+	}
+
+	return index, nil
+}
+
+// ResolveTeams replaces the names of the match's teams with their canonical names.
+func ResolveTeams(m *match.Match, registry TeamResolver) error {
+	// Both names are resolved before either error is returned so that a resolver sees every name.
+	home, homeErr := registry.Resolve(m.Home.Name)
+	away, awayErr := registry.Resolve(m.Away.Name)
+	if homeErr != nil {
+		return homeErr
+	}
+
+	if awayErr != nil {
+		return awayErr
+	}
+
+	if home == away {
+		return fmt.Errorf("%s can't play itself", home.Name)
+	}
+
+	m.Home.Name = home.Name
+	m.Away.Name = away.Name
+
+	return nil
+}
+
+func parseRecord(record []string, header map[Column]int, layouts []string) (*match.Match, error) {
+	index := header
+	if index == nil {
+		var err error
+		if index, err = positionalIndex(record); err != nil {
+			return nil, err
+		}
+	}
+
+	field := func(column Column) (string, bool) {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return "", false
+		}
+
+		return strings.TrimSpace(record[i]), true
+	}
+
+	// Only the undated positional form has no date column, and its matches are dated today.
+	date := time.Now()
+	if _, dated := index[ColumnDate]; dated {
+		value, ok := field(ColumnDate)
+		if !ok {
+			return nil, fmt.Errorf("the %s is missing", ColumnDate)
+		}
+
+		var err error
+		if date, err = parseDate(value, layouts); err != nil {
+			return nil, err
+		}
+	}
+
+	location, _ := field(ColumnLocation)
+	venue, _ := field(ColumnVenue)
+	city, _ := field(ColumnCity)
+
+	site, err := match.ParseSite([]string{location, venue, city})
+	if err != nil {
+		return nil, err
+	}
+
+	homeName, _ := field(ColumnHome)
+	homeScore, _ := field(ColumnHomeScore)
+	awayName, _ := field(ColumnAway)
+	awayScore, _ := field(ColumnAwayScore)
+
+	m, err := match.NewMatchFromFields(date, homeName, homeScore, awayName, awayScore, site)
+	if err != nil {
+		return nil, err
+	}
+
+	if value, _ := field(ColumnState); value != "" {
+		if err = setState(m, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// setState records the state named in a record's state column, checking that only a completed match
+// has a score.
+func setState(m *match.Match, value string) error {
+	state, err := match.ParseState(value)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case state == match.StateCompleted && !m.IsPlayed():
+		return fmt.Errorf("a completed match requires a score")
+	case state != match.StateCompleted && m.IsPlayed():
+		return fmt.Errorf("a %s match can't have a score", state)
+	}
+
+	m.State = state
+
+	return nil
+}
+
+func parseDate(value string, layouts []string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("the date is empty")
+	}
+
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date <%s>", value)
+}
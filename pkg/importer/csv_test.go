@@ -0,0 +1,254 @@
+package importer_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadCSV", func() {
+	read := func(input string, opts importer.Options) ([]*match.Match, importer.Errors) {
+		return importer.ReadCSV(strings.NewReader(input), opts)
+	}
+
+	It("should read positional records", func() {
+		// Arrange
+		input := "# results\n2023-09-01,Duke,2,UNC,1\n\n2023-09-05,UNC,1,Duke,1 (4-3 PK),N,WakeMed Soccer Park,Cary\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(2))
+		Expect(matches[0].Date).To(Equal(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(matches[0].Home.Name).To(Equal("Duke"))
+		Expect(matches[1].Decision).To(Equal(match.DecisionShootout))
+		Expect(matches[1].Venue).To(Equal("WakeMed Soccer Park"))
+		Expect(matches[1].Neutral).To(BeTrue())
+	})
+
+	It("should read undated records", func() {
+		// Act
+		matches, errs := read("Duke,2,UNC,1\n", importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Away.Name).To(Equal("UNC"))
+	})
+
+	It("should read quoted team names containing commas", func() {
+		// Act
+		matches, errs := read(`2023-09-01,"California, Berkeley",0,"Texas A&M-Corpus Christi",3`+"\n", importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches[0].Home.Name).To(Equal("California, Berkeley"))
+		Expect(matches[0].Away.Score).To(Equal(3))
+	})
+
+	It("should map columns by their header names", func() {
+		// Arrange
+		input := "Venue,Away Team,Away_Score,Home Team,home-score,Date,Site\n" +
+			"Koskinen Stadium,UNC,1,Duke,2,09/01/2023,N\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Home.Name).To(Equal("Duke"))
+		Expect(matches[0].Home.Score).To(Equal(2))
+		Expect(matches[0].Away.Name).To(Equal("UNC"))
+		Expect(matches[0].Venue).To(Equal("Koskinen Stadium"))
+		Expect(matches[0].Neutral).To(BeTrue())
+		Expect(matches[0].Date).To(Equal(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should reject a header without a date column", func() {
+		// Arrange
+		input := "Home Team,Home Score,Away Team,Away Score\nDuke,2,UNC,1\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{FileName: "results.csv"})
+
+		// Assert
+		Expect(matches).To(BeEmpty())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Line).To(Equal(1))
+		Expect(errs[0].Err).To(MatchError("the header has no date column"))
+	})
+
+	It("should reject a record without the date its header declares", func() {
+		// Arrange
+		input := "home,home_score,away,away_score,date\nDuke,2,UNC,1,2023-09-01\nA,1,B,0\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{})
+
+		// Assert
+		Expect(matches).To(HaveLen(1))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Line).To(Equal(3))
+		Expect(errs[0].Err).To(MatchError("the date is missing"))
+	})
+
+	It("should use a configured column mapping, date layout and delimiter", func() {
+		// Arrange
+		opts := importer.Options{
+			Columns: importer.Columns{
+				importer.ColumnDate:      {"Played"},
+				importer.ColumnHome:      {"Host"},
+				importer.ColumnHomeScore: {"HG"},
+				importer.ColumnAway:      {"Guest"},
+				importer.ColumnAwayScore: {"GG"},
+			},
+			DateLayouts: []string{"02.01.2006"},
+			Comma:       ';',
+		}
+		input := "Played;Host;HG;Guest;GG\n01.09.2023;Duke;2;UNC;1\n"
+
+		// Act
+		matches, errs := read(input, opts)
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Date).To(Equal(time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should read records without scores as scheduled fixtures", func() {
+		// Act
+		matches, errs := read("2023-09-01,Duke,2,UNC,1\n2023-10-01,UNC,,Duke,\n", importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(2))
+		Expect(matches[0].State).To(Equal(match.StateCompleted))
+		Expect(matches[1].State).To(Equal(match.StateScheduled))
+		Expect(matches[1].IsPlayed()).To(BeFalse())
+	})
+
+	It("should read the state column", func() {
+		// Arrange
+		input := "date,home,home score,away,away score,status\n" +
+			"2023-09-01,Duke,2,UNC,1,Final\n" +
+			"2023-09-08,Duke,,NC State,,PPD\n" +
+			"2023-09-15,Duke,,Clemson,,canceled\n" +
+			"2023-09-22,Duke,,Virginia,,\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{})
+
+		// Assert
+		Expect(errs).To(BeEmpty())
+		Expect(matches).To(HaveLen(4))
+		Expect(matches[0].State).To(Equal(match.StateCompleted))
+		Expect(matches[1].State).To(Equal(match.StatePostponed))
+		Expect(matches[2].State).To(Equal(match.StateCancelled))
+		Expect(matches[3].State).To(Equal(match.StateScheduled))
+	})
+
+	It("should report a state that doesn't agree with the scores", func() {
+		// Arrange
+		input := "date,home,home score,away,away score,state\n" +
+			"2023-09-01,Duke,2,UNC,1,postponed\n" +
+			"2023-09-08,Duke,,NC State,,completed\n" +
+			"2023-09-15,Duke,1,Clemson,0,delayed\n"
+
+		// Act
+		_, errs := read(input, importer.Options{})
+
+		// Assert
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0].Error()).To(Equal("line 2: unable to parse match: a postponed match can't have a score"))
+		Expect(errs[1].Error()).To(Equal("line 3: unable to parse match: a completed match requires a score"))
+		Expect(errs[2].Error()).To(Equal("line 4: unable to parse match: unknown state <delayed>"))
+	})
+
+	It("should report every invalid record with its line number", func() {
+		// Arrange
+		input := "2023-09-01,Duke,2,UNC,1\n" +
+			"2023-09-02,Duke,x,UNC,1\n" +
+			"2023-13-45,Duke,2,UNC,1\n" +
+			"2023-09-04,Duke,2\n" +
+			"2023-09-05,Duke,2,\"UNC,1\n"
+
+		// Act
+		matches, errs := read(input, importer.Options{FileName: "results.csv"})
+
+		// Assert
+		Expect(matches).To(HaveLen(1))
+		Expect(errs).To(HaveLen(4))
+		Expect(errs[0].Error()).To(Equal("results.csv:2: unable to parse match: invalid home score <x>"))
+		Expect(errs[1].Error()).To(Equal("results.csv:3: unable to parse match: invalid date <2023-13-45>"))
+		Expect(errs[2].Error()).To(Equal("results.csv:4: unable to parse match: expected 4 to 8 columns but found 3"))
+		Expect(errs[3].Line).To(Equal(5))
+	})
+
+	It("should unwrap to the underlying error", func() {
+		// Act
+		_, errs := read("Duke,2,Duke,1\n", importer.Options{})
+
+		// Assert
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(Equal("line 1: unable to parse match: Duke can't play itself"))
+		Expect(errors.Unwrap(errs[0])).To(MatchError("Duke can't play itself"))
+	})
+
+	It("should join every error into one message", func() {
+		// Act
+		_, errs := read("Duke,x,UNC,1\nDuke,1,UNC,y\n", importer.Options{})
+
+		// Assert
+		Expect(errs.Error()).To(Equal("line 1: unable to parse match: invalid home score <x>\n" +
+			"line 2: unable to parse match: invalid away score <y>"))
+	})
+
+	Describe("with a team registry", func() {
+		var registry *team.Registry
+
+		BeforeEach(func() {
+			var err error
+			registry, err = team.LoadRegistry(strings.NewReader("name,aliases,division\nNorth Carolina,UNC|Carolina,I\nDuke,,I\n"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should resolve each team to its canonical name", func() {
+			// Act
+			matches, errs := read("2023-09-01,UNC,2,duke,1\n", importer.Options{Teams: registry})
+
+			// Assert
+			Expect(errs).To(BeEmpty())
+			Expect(matches[0].Home.Name).To(Equal("North Carolina"))
+			Expect(matches[0].Away.Name).To(Equal("Duke"))
+		})
+
+		It("should report a team that isn't registered", func() {
+			// Act
+			matches, errs := read("2023-09-01,UNC,2,Duke,1\n2023-09-04,NC State,0,Duke,1\n", importer.Options{Teams: registry})
+
+			// Assert
+			Expect(matches).To(HaveLen(1))
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(Equal("line 2: unable to parse match: unknown team NC State"))
+		})
+
+		It("should report a team playing itself under another name", func() {
+			// Act
+			_, errs := read("2023-09-01,UNC,2,Carolina,1\n", importer.Options{Teams: registry})
+
+			// Assert
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Error()).To(Equal("line 1: unable to parse match: North Carolina can't play itself"))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineError is an error reading a single record of an input file.
+type LineError struct {
+	FileName string
+	Line     int
+	Err      error
+}
+
+func (e *LineError) Error() string {
+	if e.FileName == "" {
+		return fmt.Sprintf("line %d: unable to parse match: %v", e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: unable to parse match: %v", e.FileName, e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is every error found while reading an input file, in the order they were found.
+type Errors []*LineError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
@@ -8,6 +8,10 @@ type Builder struct {
 	homeScore int
 	awayName  string
 	awayScore int
+	site      Site
+	decision  Decision
+	shootout  [2]int
+	state     State
 }
 
 func NewBuilder() *Builder {
@@ -17,6 +21,10 @@ func NewBuilder() *Builder {
 		homeScore: 0,
 		awayName:  "",
 		awayScore: 0,
+		site:      Site{},
+		decision:  DecisionRegulation,
+		shootout:  [2]int{0, 0},
+		state:     StateCompleted,
 	}
 }
 
@@ -45,6 +53,38 @@ func (m *Builder) BuildAwayScore(awayScore int) *Builder {
 	return m
 }
 
+func (m *Builder) BuildNeutral(neutral bool) *Builder {
+	m.site.Neutral = neutral
+	return m
+}
+
+func (m *Builder) BuildVenue(venue, city string) *Builder {
+	m.site.Venue = venue
+	m.site.City = city
+	return m
+}
+
+func (m *Builder) BuildSite(site Site) *Builder {
+	m.site = site
+	return m
+}
+
+func (m *Builder) BuildDecision(decision Decision) *Builder {
+	m.decision = decision
+	return m
+}
+
+func (m *Builder) BuildShootout(homeShootout, awayShootout int) *Builder {
+	m.shootout = [2]int{homeShootout, awayShootout}
+	return m
+}
+
+// BuildState sets whether the match has been played, such as StateScheduled for a fixture.
+func (m *Builder) BuildState(state State) *Builder {
+	m.state = state
+	return m
+}
+
 func (m *Builder) GetInstance() *Match {
 	match := NewMatch()
 
@@ -53,6 +93,11 @@ func (m *Builder) GetInstance() *Match {
 	match.Home.Score = m.homeScore
 	match.Away.Name = m.awayName
 	match.Away.Score = m.awayScore
+	match.Site = m.site
+	match.Decision = m.decision
+	match.Home.Shootout = m.shootout[0]
+	match.Away.Shootout = m.shootout[1]
+	match.State = m.state
 
 	return match
 }
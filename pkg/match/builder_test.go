@@ -41,5 +41,43 @@ var _ = Describe("Builder", func() {
 		Expect(match.Home.Score).To(Equal(homeScore))
 		Expect(match.Away.Name).To(Equal(awayName))
 		Expect(match.Away.Score).To(Equal(awayScore))
+		Expect(match.Neutral).To(BeFalse())
+	})
+
+	It("should build a completed match unless a state is given", func() {
+		// Act
+		completed := builder.BuildHomeName("A").BuildAwayName("B").GetInstance()
+		fixture := builder.BuildState(match.StateScheduled).GetInstance()
+
+		// Assert
+		Expect(completed.State).To(Equal(match.StateCompleted))
+		Expect(fixture.State).To(Equal(match.StateScheduled))
+		Expect(fixture.IsPlayed()).To(BeFalse())
+	})
+
+	It("should be able to build a neutral site match", func() {
+		// Act
+		match := builder.
+			BuildHomeName("Ashland Blazer").
+			BuildAwayName("Raceland").
+			BuildNeutral(true).
+			GetInstance()
+
+		// Assert
+		Expect(match.Neutral).To(BeTrue())
+	})
+
+	It("should be able to build a match with a venue", func() {
+		// Act
+		match := builder.
+			BuildHomeName("Ashland Blazer").
+			BuildAwayName("Raceland").
+			BuildVenue("Putnam Stadium", "Ashland").
+			GetInstance()
+
+		// Assert
+		Expect(match.Neutral).To(BeFalse())
+		Expect(match.Venue).To(Equal("Putnam Stadium"))
+		Expect(match.City).To(Equal("Ashland"))
 	})
 })
@@ -0,0 +1,298 @@
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decision is how a match was decided.
+type Decision int
+
+const (
+	DecisionRegulation Decision = iota
+	DecisionOvertime
+	DecisionShootout
+	DecisionForfeit
+)
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionRegulation:
+		return "regulation"
+	case DecisionOvertime:
+		return "overtime"
+	case DecisionShootout:
+		return "shootout"
+	case DecisionForfeit:
+		return "forfeit"
+	}
+
+	return "unknown"
+}
+
+// MarshalText encodes the decision as its name.
+func (d Decision) MarshalText() ([]byte, error) {
+	if d < DecisionRegulation || d > DecisionForfeit {
+		return nil, fmt.Errorf("unknown decision %d", d)
+	}
+
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a decision from its name.
+func (d *Decision) UnmarshalText(text []byte) error {
+	for decision := DecisionRegulation; decision <= DecisionForfeit; decision++ {
+		if string(text) == decision.String() {
+			*d = decision
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown decision <%s>", text)
+}
+
+// Credit is how a match is credited in the records of its teams.
+type Credit int
+
+const (
+	// CreditScore credits the match by its final score, so a level score is a tie.
+	CreditScore Credit = iota
+
+	// CreditShootoutWinner credits the winner of a shootout with a win and the other team with a loss.
+	CreditShootoutWinner
+
+	// CreditTie credits both teams with a tie whatever the score.
+	CreditTie
+
+	// CreditExcluded leaves the match out of both teams' records.
+	CreditExcluded
+)
+
+func (c Credit) String() string {
+	switch c {
+	case CreditScore:
+		return "score"
+	case CreditShootoutWinner:
+		return "shootout-winner"
+	case CreditTie:
+		return "tie"
+	case CreditExcluded:
+		return "excluded"
+	}
+
+	return "unknown"
+}
+
+// MarshalText encodes the credit as its name.
+func (c Credit) MarshalText() ([]byte, error) {
+	if c < CreditScore || c > CreditExcluded {
+		return nil, fmt.Errorf("unknown credit %d", c)
+	}
+
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a credit from its name.
+func (c *Credit) UnmarshalText(text []byte) error {
+	for credit := CreditScore; credit <= CreditExcluded; credit++ {
+		if string(text) == credit.String() {
+			*c = credit
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown credit <%s>", text)
+}
+
+// DecisionRules is how matches are credited according to how they were decided.  Decisions
+// without a rule are credited by their final score.
+type DecisionRules map[Decision]Credit
+
+// Credit returns how a match with the specified decision is credited.
+func (r DecisionRules) Credit(decision Decision) Credit {
+	if credit, ok := r[decision]; ok {
+		return credit
+	}
+
+	return CreditScore
+}
+
+// Validate checks that every rule uses a known decision and credit.
+func (r DecisionRules) Validate() error {
+	for decision, credit := range r {
+		if decision < DecisionRegulation || decision > DecisionForfeit {
+			return fmt.Errorf("unknown decision %d", decision)
+		}
+
+		if credit < CreditScore || credit > CreditExcluded {
+			return fmt.Errorf("unknown credit %d for %s", credit, decision)
+		}
+	}
+
+	return nil
+}
+
+// annotationPattern matches a decision annotation such as "(OT)", "(2OT)", "(PK)", "(4-3 PK)" or "(FF)".
+var annotationPattern = regexp.MustCompile(`^\(\s*(?:(\d+)\s*-\s*(\d+)\s+)?([A-Za-z0-9]+)\s*\)$`)
+
+// decided is how a match was decided, as read from its annotation.
+type decided struct {
+	decision     Decision
+	overtimes    int
+	homeShootout int
+	awayShootout int
+}
+
+// ParseDecision parses a decision annotation such as "(OT)", "(2OT)", "(PK)", "(4-3 PK)" or "(FF)" and
+// returns the decision with the home and away shootout scores.  An empty annotation is a regulation decision.
+func ParseDecision(annotation string) (Decision, int, int, error) {
+	d, err := parseAnnotation(annotation)
+	if err != nil {
+		return DecisionRegulation, 0, 0, err
+	}
+
+	return d.decision, d.homeShootout, d.awayShootout, nil
+}
+
+// parseAnnotation parses a decision annotation, including the number of overtime periods when there
+// was more than one.
+func parseAnnotation(annotation string) (decided, error) {
+	annotation = strings.TrimSpace(annotation)
+	if annotation == "" {
+		return decided{}, nil
+	}
+
+	groups := annotationPattern.FindStringSubmatch(annotation)
+	if groups == nil {
+		return decided{}, fmt.Errorf("invalid decision <%s>", annotation)
+	}
+
+	decision, overtimes, err := parseDecisionCode(groups[3])
+	if err != nil {
+		return decided{}, err
+	}
+
+	if groups[1] == "" {
+		return decided{decision: decision, overtimes: overtimes}, nil
+	}
+
+	if decision != DecisionShootout {
+		return decided{}, fmt.Errorf("only a shootout can have a shootout score <%s>", annotation)
+	}
+
+	homeShootout, _ := strconv.Atoi(groups[1])
+	awayShootout, _ := strconv.Atoi(groups[2])
+
+	return decided{decision: decision, homeShootout: homeShootout, awayShootout: awayShootout}, nil
+}
+
+// parseDecisionCode returns the decision a code names and, for a match that went to more than one
+// overtime period, the number of periods.
+func parseDecisionCode(code string) (Decision, int, error) {
+	code = strings.ToUpper(code)
+
+	switch {
+	case code == "PK" || code == "PKS" || code == "SO":
+		return DecisionShootout, 0, nil
+	case code == "FF" || code == "F" || code == "FORFEIT":
+		return DecisionForfeit, 0, nil
+	case code == "OT" || code == "1OT":
+		return DecisionOvertime, 0, nil
+	case strings.HasSuffix(code, "OT"):
+		if overtimes, err := strconv.Atoi(strings.TrimSuffix(code, "OT")); err == nil && overtimes > 1 {
+			return DecisionOvertime, overtimes, nil
+		}
+	}
+
+	return DecisionRegulation, 0, fmt.Errorf("unknown decision <%s>", code)
+}
+
+// parseScore parses an away score that may be followed by a decision annotation, such as "1 (4-3 PK)".
+func parseScore(token string) (int, decided, error) {
+	scoreToken, annotation, _ := strings.Cut(token, "(")
+	if annotation != "" {
+		annotation = "(" + annotation
+	}
+
+	score, err := strconv.Atoi(strings.TrimSpace(scoreToken))
+	if err != nil {
+		return 0, decided{}, fmt.Errorf("invalid away score <%s>", strings.TrimSpace(scoreToken))
+	}
+
+	d, err := parseAnnotation(annotation)
+	if err != nil {
+		return 0, decided{}, err
+	}
+
+	return score, d, nil
+}
+
+// setDecision records how the match was decided, checking that a shootout followed a level score.
+func (m *Match) setDecision(d decided) error {
+	if d.decision == DecisionShootout && m.Home.Score != m.Away.Score {
+		return fmt.Errorf("a shootout requires a level score")
+	}
+
+	m.Decision = d.decision
+	m.Overtimes = d.overtimes
+	m.Home.Shootout = d.homeShootout
+	m.Away.Shootout = d.awayShootout
+
+	return nil
+}
+
+// Annotation returns the decision annotation for the match, such as "(2OT)" or "(4-3 PK)", or an
+// empty string for a match decided in regulation.
+func (m *Match) Annotation() string {
+	switch m.Decision {
+	case DecisionOvertime:
+		if m.Overtimes > 1 {
+			return fmt.Sprintf("(%dOT)", m.Overtimes)
+		}
+		return "(OT)"
+	case DecisionShootout:
+		if m.Home.Shootout == 0 && m.Away.Shootout == 0 {
+			return "(PK)"
+		}
+		return fmt.Sprintf("(%d-%d PK)", m.Home.Shootout, m.Away.Shootout)
+	case DecisionForfeit:
+		return "(FF)"
+	}
+
+	return ""
+}
+
+// ResultUnder returns the outcome of the match for the specified team when matches are credited
+// according to the rules.  It returns ResultNone when the team isn't in the match, the match hasn't
+// been played or the rules exclude the match.
+func (m *Match) ResultUnder(teamName string, rules DecisionRules) Result {
+	if !m.Contains(teamName) || !m.IsPlayed() {
+		return ResultNone
+	}
+
+	switch rules.Credit(m.Decision) {
+	case CreditExcluded:
+		return ResultNone
+	case CreditTie:
+		return ResultTie
+	case CreditShootoutWinner:
+		if m.IsDraw() && m.Home.Shootout != m.Away.Shootout {
+			if m.IsHomeTeam(teamName) == (m.Home.Shootout > m.Away.Shootout) {
+				return ResultWin
+			}
+			return ResultLoss
+		}
+	}
+
+	if m.IsDraw() {
+		return ResultTie
+	}
+
+	if m.IsWinner(teamName) {
+		return ResultWin
+	}
+
+	return ResultLoss
+}
@@ -0,0 +1,213 @@
+package match_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Decision", func() {
+	Describe("ParseDecision", func() {
+		DescribeTable("parses a decision annotation",
+			func(annotation string, decision match.Decision, homeShootout, awayShootout int) {
+				// Act
+				d, home, away, err := match.ParseDecision(annotation)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(d).To(Equal(decision))
+				Expect(home).To(Equal(homeShootout))
+				Expect(away).To(Equal(awayShootout))
+			},
+			Entry("empty", "", match.DecisionRegulation, 0, 0),
+			Entry("overtime", "(OT)", match.DecisionOvertime, 0, 0),
+			Entry("double overtime", "(2OT)", match.DecisionOvertime, 0, 0),
+			Entry("penalty kicks", "(PK)", match.DecisionShootout, 0, 0),
+			Entry("penalty kicks with a score", "(4-3 PK)", match.DecisionShootout, 4, 3),
+			Entry("shootout with a score", "( 5 - 6 so )", match.DecisionShootout, 5, 6),
+			Entry("forfeit", "(FF)", match.DecisionForfeit, 0, 0),
+		)
+
+		It("returns an error for an unknown decision", func() {
+			// Act
+			_, _, _, err := match.ParseDecision("(XYZ)")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("unknown decision <XYZ>"))
+		})
+
+		It("returns an error for an annotation without parentheses", func() {
+			// Act
+			_, _, _, err := match.ParseDecision("PK")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid decision <PK>"))
+		})
+
+		DescribeTable("returns an error for an overtime without periods",
+			func(annotation string) {
+				// Act
+				_, _, _, err := match.ParseDecision(annotation)
+
+				// Assert
+				Expect(err).To(MatchError("unknown decision <0OT>"))
+			},
+			Entry("no periods", "(0OT)"),
+			Entry("lowercase", "(0ot)"),
+		)
+
+		It("returns an error for a shootout score on another decision", func() {
+			// Act
+			_, _, _, err := match.ParseDecision("(4-3 OT)")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NewMatchFromString", func() {
+		It("parses a match decided by penalty kicks", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,1 (4-3 PK)")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Home.Score).To(Equal(1))
+			Expect(myMatch.Away.Score).To(Equal(1))
+			Expect(myMatch.Decision).To(Equal(match.DecisionShootout))
+			Expect(myMatch.Home.Shootout).To(Equal(4))
+			Expect(myMatch.Away.Shootout).To(Equal(3))
+			Expect(myMatch.IsDraw()).To(BeTrue())
+		})
+
+		It("parses an undated match decided in overtime", func() {
+			// Act
+			myMatch := match.NewMatchFromString("Team A,2,Team B,1 (OT)")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Decision).To(Equal(match.DecisionOvertime))
+		})
+
+		It("returns nil for a shootout after a decisive score", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,2,Team B,1 (4-3 PK)")
+
+			// Assert
+			Expect(myMatch).To(BeNil())
+		})
+	})
+
+	DescribeTable("keeps the number of overtime periods",
+		func(line, expected string, overtimes int) {
+			// Act
+			myMatch := match.NewMatchFromString(line)
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Decision).To(Equal(match.DecisionOvertime))
+			Expect(myMatch.Overtimes).To(Equal(overtimes))
+			Expect(myMatch.ToString()).To(Equal(expected))
+			Expect(match.NewMatchFromString("2023-09-19," + myMatch.ToString())).To(Equal(myMatch))
+		},
+		Entry("one period", "2023-09-19,Team A,2,Team B,1 (OT)", "Team A,2,Team B,1 (OT)", 0),
+		Entry("one numbered period", "2023-09-19,Team A,2,Team B,1 (1OT)", "Team A,2,Team B,1 (OT)", 0),
+		Entry("two periods", "2023-09-19,Team A,2,Team B,1 (2OT)", "Team A,2,Team B,1 (2OT)", 2),
+		Entry("three periods", "2023-09-19,Team A,2,Team B,1 (3ot)", "Team A,2,Team B,1 (3OT)", 3),
+	)
+
+	Describe("ToString", func() {
+		It("includes the decision annotation", func() {
+			// Arrange
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,1 (4-3 PK)")
+
+			// Act
+			answer := myMatch.ToString()
+
+			// Assert
+			Expect(answer).To(Equal("Team A,1,Team B,1 (4-3 PK)"))
+		})
+	})
+
+	Describe("ResultUnder", func() {
+		var myMatch *match.Match
+
+		BeforeEach(func() {
+			myMatch = match.NewBuilder().
+				BuildHomeName("Team A").
+				BuildHomeScore(1).
+				BuildAwayName("Team B").
+				BuildAwayScore(1).
+				BuildDecision(match.DecisionShootout).
+				BuildShootout(3, 4).
+				GetInstance()
+		})
+
+		It("credits a shootout as a tie by default", func() {
+			Expect(myMatch.ResultUnder("Team A", nil)).To(Equal(match.ResultTie))
+			Expect(myMatch.ResultFor("Team B")).To(Equal(match.ResultTie))
+		})
+
+		It("credits the shootout winner when the rules say so", func() {
+			// Arrange
+			rules := match.DecisionRules{match.DecisionShootout: match.CreditShootoutWinner}
+
+			// Assert
+			Expect(myMatch.ResultUnder("Team A", rules)).To(Equal(match.ResultLoss))
+			Expect(myMatch.ResultUnder("Team B", rules)).To(Equal(match.ResultWin))
+		})
+
+		It("credits a tie whatever the score when the rules say so", func() {
+			// Arrange
+			myMatch.Decision = match.DecisionOvertime
+			myMatch.Home.Score = 2
+			rules := match.DecisionRules{match.DecisionOvertime: match.CreditTie}
+
+			// Assert
+			Expect(myMatch.ResultUnder("Team A", rules)).To(Equal(match.ResultTie))
+			Expect(myMatch.ResultFor("Team A")).To(Equal(match.ResultWin))
+		})
+
+		It("leaves the raw-score helpers to the final score", func() {
+			// Arrange
+			myMatch.Decision = match.DecisionOvertime
+			myMatch.Home.Score = 2
+			rules := match.DecisionRules{match.DecisionOvertime: match.CreditTie}
+
+			// Assert
+			Expect(myMatch.ResultUnder("Team A", rules)).To(Equal(match.ResultTie))
+			Expect(myMatch.IsWinner("Team A")).To(BeTrue())
+			Expect(myMatch.IsDraw()).To(BeFalse())
+			Expect(myMatch.WinValue("Team A")).To(Equal(1.0))
+		})
+
+		It("excludes the match when the rules say so", func() {
+			// Arrange
+			myMatch.Decision = match.DecisionForfeit
+			rules := match.DecisionRules{match.DecisionForfeit: match.CreditExcluded}
+
+			// Assert
+			Expect(myMatch.ResultUnder("Team A", rules)).To(Equal(match.ResultNone))
+		})
+	})
+
+	Describe("DecisionRules", func() {
+		It("credits a decision without a rule by its score", func() {
+			Expect(match.DecisionRules{}.Credit(match.DecisionForfeit)).To(Equal(match.CreditScore))
+		})
+
+		It("rejects an unknown credit", func() {
+			// Arrange
+			rules := match.DecisionRules{match.DecisionShootout: match.Credit(42)}
+
+			// Act
+			err := rules.Validate()
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("unknown credit 42 for shootout"))
+		})
+	})
+})
@@ -2,7 +2,6 @@ package match
 
 import (
 	"math/rand"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -19,14 +18,24 @@ func NewFactory(builder *Builder) *Factory {
 	}
 }
 
-// Create creates a new match with the given parameters.
-func (m *Factory) Create(date time.Time, homeName string, homeScore int, awayName string, awayScore int) *Match {
+// Create creates a new match with the given parameters.  The match is played at the home team's
+// venue unless a site is given.
+func (m *Factory) Create(date time.Time, homeName string, homeScore int, awayName string, awayScore int, site ...Site) *Match {
+	var matchSite Site
+	if len(site) > 0 {
+		matchSite = site[0]
+	}
+
 	return m.builder.
 		BuildDate(date).
 		BuildHomeName(homeName).
 		BuildHomeScore(homeScore).
 		BuildAwayName(awayName).
 		BuildAwayScore(awayScore).
+		BuildSite(matchSite).
+		BuildDecision(DecisionRegulation).
+		BuildShootout(0, 0).
+		BuildState(StateCompleted).
 		GetInstance()
 }
 
@@ -43,27 +52,30 @@ func (m *Factory) CreateWithRandomDate(homeName string, homeScore int, awayName
 	return m.Create(randate(), homeName, homeScore, awayName, awayScore)
 }
 
+// CreateFromString creates a match from a string in the form date,home,homeScore,away,awayScore with
+// optional trailing location, venue and city columns.  The away score may be followed by a decision
+// annotation, and a match with both scores blank is a scheduled fixture.  It returns nil when the
+// match can't be parsed.
 func (m *Factory) CreateFromString(input string) *Match {
 	tokens := strings.Split(input, ",")
+	if len(tokens) < 5 || len(tokens) > 8 {
+		return nil
+	}
 
 	date, err := time.Parse("2006-01-02", tokens[0])
 	if err != nil {
 		return nil
 	}
 
-	homeName := tokens[1]
-
-	homeScore, err := strconv.Atoi(tokens[2])
+	site, err := ParseSite(tokens[5:])
 	if err != nil {
 		return nil
 	}
 
-	awayName := tokens[3]
-
-	awayScore, err := strconv.Atoi(tokens[4])
-	if err != nil {
+	match := m.Create(date, tokens[1], 0, tokens[3], 0, site)
+	if err = match.parseScores(tokens[2], tokens[4]); err != nil {
 		return nil
 	}
 
-	return m.Create(date, homeName, homeScore, awayName, awayScore)
+	return match
 }
@@ -1,6 +1,8 @@
 package match_test
 
 import (
+	"time"
+
 	"github.com/jedi-knights/rpi/pkg/match"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -33,4 +35,87 @@ var _ = Describe("Factory", func() {
 			Expect(match.Away.Score).To(Equal(0))
 		})
 	})
+
+	Describe("Create", func() {
+		It("should not carry a neutral site over from the shared builder", func() {
+			// Arrange
+			builder.BuildNeutral(true)
+
+			// Act
+			match := factory.CreateWithRandomDate("Team A", 1, "Team B", 0)
+
+			// Assert
+			Expect(match.Neutral).To(BeFalse())
+		})
+
+		It("should create a match at the given site", func() {
+			// Arrange
+			site := match.Site{Neutral: true, Venue: "WakeMed Soccer Park", City: "Cary"}
+
+			// Act
+			myMatch := factory.Create(time.Now(), "Team A", 1, "Team B", 0, site)
+
+			// Assert
+			Expect(myMatch.Site).To(Equal(site))
+		})
+	})
+
+	Describe("CreateFromString", func() {
+		It("should return nil for a line that is too short", func() {
+			// Act
+			myMatch := factory.CreateFromString("2023-09-19,Team A,1")
+
+			// Assert
+			Expect(myMatch).To(BeNil())
+		})
+
+		It("should parse the optional site columns", func() {
+			// Act
+			myMatch := factory.CreateFromString("2023-09-19,Team A,1,Team B,0,N,WakeMed Soccer Park,Cary")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Site).To(Equal(match.Site{Neutral: true, Venue: "WakeMed Soccer Park", City: "Cary"}))
+		})
+
+		It("should parse a fixture like NewMatchFromString", func() {
+			// Act
+			myMatch := factory.CreateFromString("2023-05-09,A,,B,")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.State).To(Equal(match.StateScheduled))
+			Expect(myMatch.IsPlayed()).To(BeFalse())
+			Expect(myMatch.ToString()).To(Equal(match.NewMatchFromString("2023-05-09,A,,B,").ToString()))
+		})
+
+		It("should parse a decision annotation", func() {
+			// Act
+			myMatch := factory.CreateFromString("2023-05-09,A,1,B,1 (4-3 PK)")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Decision).To(Equal(match.DecisionShootout))
+			Expect(myMatch.IsPlayed()).To(BeTrue())
+		})
+
+		It("should not carry a fixture's state over to the next match", func() {
+			// Arrange
+			Expect(factory.CreateFromString("2023-05-09,A,,B,")).NotTo(BeNil())
+
+			// Act
+			myMatch := factory.Create(time.Now(), "A", 1, "B", 0)
+
+			// Assert
+			Expect(myMatch.IsPlayed()).To(BeTrue())
+		})
+
+		It("should return nil for a single blank score", func() {
+			// Act
+			myMatch := factory.CreateFromString("2023-05-09,A,1,B,")
+
+			// Assert
+			Expect(myMatch).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,38 @@
+package match
+
+// Location describes where a match was played from the point of view of one of its teams.
+type Location int
+
+const (
+	LocationHome Location = iota
+	LocationAway
+	LocationNeutral
+)
+
+func (l Location) String() string {
+	switch l {
+	case LocationHome:
+		return "home"
+	case LocationAway:
+		return "away"
+	case LocationNeutral:
+		return "neutral"
+	}
+
+	return "unknown"
+}
+
+// LocationOf returns where the match was played from the point of view of the specified team.
+// Every match played at a neutral site is neutral for both teams.  A team that isn't in the match
+// is treated as the away team.
+func (m *Match) LocationOf(teamName string) Location {
+	if m.Neutral {
+		return LocationNeutral
+	}
+
+	if m.IsHomeTeam(teamName) {
+		return LocationHome
+	}
+
+	return LocationAway
+}
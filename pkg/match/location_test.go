@@ -0,0 +1,62 @@
+package match_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Location", func() {
+	var myMatch *match.Match
+
+	BeforeEach(func() {
+		myMatch = match.NewBuilder().
+			BuildHomeName("Team A").
+			BuildAwayName("Team B").
+			GetInstance()
+	})
+
+	AfterEach(func() {
+		myMatch = nil
+	})
+
+	Describe("LocationOf", func() {
+		It("returns home for the home team", func() {
+			// Act
+			location := myMatch.LocationOf("Team A")
+
+			// Assert
+			Expect(location).To(Equal(match.LocationHome))
+		})
+
+		It("returns away for the away team", func() {
+			// Act
+			location := myMatch.LocationOf("Team B")
+
+			// Assert
+			Expect(location).To(Equal(match.LocationAway))
+		})
+
+		It("returns neutral for both teams when the match is at a neutral site", func() {
+			// Arrange
+			myMatch.Neutral = true
+
+			// Act
+			homeLocation := myMatch.LocationOf("Team A")
+			awayLocation := myMatch.LocationOf("Team B")
+
+			// Assert
+			Expect(homeLocation).To(Equal(match.LocationNeutral))
+			Expect(awayLocation).To(Equal(match.LocationNeutral))
+		})
+	})
+
+	Describe("String", func() {
+		It("returns the name of the location", func() {
+			Expect(match.LocationHome.String()).To(Equal("home"))
+			Expect(match.LocationAway.String()).To(Equal("away"))
+			Expect(match.LocationNeutral.String()).To(Equal("neutral"))
+			Expect(match.Location(42).String()).To(Equal("unknown"))
+		})
+	})
+})
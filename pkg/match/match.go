@@ -9,9 +9,26 @@ import (
 )
 
 type Match struct {
+	// ID identifies the match within a schedule.  A schedule assigns one when the match is added
+	// without it.
+	ID string
+
 	Date time.Time
 	Home Status
 	Away Status
+	Site
+
+	// Decision is how the match was decided.  A shootout is recorded with a level score and the
+	// shootout scores in Home.Shootout and Away.Shootout.
+	Decision Decision
+
+	// Overtimes is the number of overtime periods played when a match went to more than one, such as 2
+	// for "(2OT)".  It is zero for a match decided in a single overtime period or without one.
+	Overtimes int
+
+	// State is whether the match has been played.  The scores of a match that hasn't been played are
+	// zero and it has no result.
+	State State
 }
 
 func NewMatch() *Match {
@@ -28,11 +45,15 @@ func NewMatch() *Match {
 	}
 }
 
+// NewMatchFromString parses a match in the form date,home,homeScore,away,awayScore with optional
+// trailing location, venue and city columns, or in the undated form home,homeScore,away,awayScore.
+// The away score may be followed by a decision annotation such as "(OT)" or "(4-3 PK)", and a match
+// with both scores blank is a scheduled fixture.  It returns nil when the match can't be parsed.
 func NewMatchFromString(matchString string) *Match {
 	var err error
 	tokens := strings.Split(matchString, ",")
 
-	if len(tokens) == 5 {
+	if len(tokens) >= 5 && len(tokens) <= 8 {
 		newMatch := NewMatch()
 
 		newMatch.Home.Name = tokens[1]
@@ -41,10 +62,10 @@ func NewMatchFromString(matchString string) *Match {
 		if newMatch.Date, err = time.Parse("2006-01-02", tokens[0]); err != nil {
 			return nil
 		}
-		if newMatch.Home.Score, err = strconv.Atoi(tokens[2]); err != nil {
+		if err = newMatch.parseScores(tokens[2], tokens[4]); err != nil {
 			return nil
 		}
-		if newMatch.Away.Score, err = strconv.Atoi(tokens[4]); err != nil {
+		if newMatch.Site, err = ParseSite(tokens[5:]); err != nil {
 			return nil
 		}
 
@@ -57,10 +78,7 @@ func NewMatchFromString(matchString string) *Match {
 		newMatch.Home.Name = tokens[0]
 		newMatch.Away.Name = tokens[2]
 
-		if newMatch.Home.Score, err = strconv.Atoi(tokens[1]); err != nil {
-			return nil
-		}
-		if newMatch.Away.Score, err = strconv.Atoi(tokens[3]); err != nil {
+		if err = newMatch.parseScores(tokens[1], tokens[3]); err != nil {
 			return nil
 		}
 
@@ -70,6 +88,91 @@ func NewMatchFromString(matchString string) *Match {
 	return nil
 }
 
+// NewMatchFromFields builds a match from its separate fields, returning an error that describes the
+// first field that can't be parsed.  The away score may be followed by a decision annotation such as
+// "(OT)" or "(4-3 PK)", and a match with both scores blank is a scheduled fixture.
+func NewMatchFromFields(date time.Time, homeName, homeScore, awayName, awayScore string, site Site) (*Match, error) {
+	newMatch := NewMatch()
+	newMatch.Date = date
+	newMatch.Home.Name = strings.TrimSpace(homeName)
+	newMatch.Away.Name = strings.TrimSpace(awayName)
+	newMatch.Site = site
+
+	if newMatch.Home.Name == "" {
+		return nil, fmt.Errorf("the home team name is empty")
+	}
+	if newMatch.Away.Name == "" {
+		return nil, fmt.Errorf("the away team name is empty")
+	}
+	if newMatch.Home.Name == newMatch.Away.Name {
+		return nil, fmt.Errorf("%s can't play itself", newMatch.Home.Name)
+	}
+	if err := newMatch.parseScores(homeScore, awayScore); err != nil {
+		return nil, err
+	}
+
+	return newMatch, nil
+}
+
+// Clone returns a copy of the match that can be changed without affecting the original.
+func (m *Match) Clone() *Match {
+	clone := *m
+	return &clone
+}
+
+// SetScore changes the score of the match to a score in the form home-away, such as "2-1", which may
+// be followed by a decision annotation such as "(OT)" or "(4-3 PK)", and marks the match completed.
+// The match is unchanged when the score can't be parsed.
+func (m *Match) SetScore(score string) error {
+	homeScore, awayScore, ok := strings.Cut(score, "-")
+	if !ok {
+		return fmt.Errorf("invalid score <%s>", score)
+	}
+
+	updated := *m
+
+	var err error
+	if updated.Home.Score, err = strconv.Atoi(strings.TrimSpace(homeScore)); err != nil {
+		return fmt.Errorf("invalid home score <%s>", strings.TrimSpace(homeScore))
+	}
+	if err = updated.parseAwayScore(awayScore); err != nil {
+		return err
+	}
+
+	updated.State = StateCompleted
+	*m = updated
+
+	return nil
+}
+
+// parseScores parses the home score and the away score with its decision annotation, or marks the
+// match scheduled when both are blank.
+func (m *Match) parseScores(homeScore, awayScore string) error {
+	if strings.TrimSpace(homeScore) == "" && strings.TrimSpace(awayScore) == "" {
+		m.State = StateScheduled
+		return nil
+	}
+
+	var err error
+	if m.Home.Score, err = strconv.Atoi(strings.TrimSpace(homeScore)); err != nil {
+		return fmt.Errorf("invalid home score <%s>", homeScore)
+	}
+
+	return m.parseAwayScore(awayScore)
+}
+
+// parseAwayScore parses the away score and the decision annotation that may follow it.
+func (m *Match) parseAwayScore(token string) error {
+	score, d, err := parseScore(token)
+	if err != nil {
+		return err
+	}
+
+	m.Away.Score = score
+
+	return m.setDecision(d)
+}
+
 func (m *Match) IsHomeTeam(teamName string) bool {
 	return teamName == m.Home.Name
 }
@@ -82,12 +185,18 @@ func (m *Match) Contains(teamName string) bool {
 	return m.IsHomeTeam(teamName) || m.IsAwayTeam(teamName)
 }
 
+// IsDraw reports whether the match was played and its final score is level.  A fixture that hasn't
+// been played isn't a draw.  Like IsWinner, IsLoser and WinValue it only looks at the score, so a match
+// settled by a shootout is a draw here whoever won the shootout; use ResultUnder for the outcome a
+// formula's DecisionRules credit.
 func (m *Match) IsDraw() bool {
-	return m.Home.Score == m.Away.Score
+	return m.IsPlayed() && m.Home.Score == m.Away.Score
 }
 
+// IsWinner reports whether the team has the higher final score.  Nobody has won a fixture that hasn't
+// been played.  It ignores how the match was decided; see ResultUnder.
 func (m *Match) IsWinner(teamName string) bool {
-	if !m.Contains(teamName) {
+	if !m.Contains(teamName) || !m.IsPlayed() {
 		return false
 	}
 
@@ -106,8 +215,10 @@ func (m *Match) IsWinner(teamName string) bool {
 	return answer
 }
 
+// IsLoser reports whether the team has the lower final score.  Nobody has lost a fixture that hasn't
+// been played.  It ignores how the match was decided; see ResultUnder.
 func (m *Match) IsLoser(teamName string) bool {
-	if !m.Contains(teamName) {
+	if !m.Contains(teamName) || !m.IsPlayed() {
 		return false
 	}
 
@@ -126,8 +237,10 @@ func (m *Match) IsLoser(teamName string) bool {
 	return answer
 }
 
+// WinValue returns the credit the team earned by the final score: 1 for a win, 0.5 for a draw and 0 for
+// a loss or a fixture that hasn't been played.  It ignores how the match was decided; see ResultUnder.
 func (m *Match) WinValue(teamName string) float64 {
-	if !m.Contains(teamName) {
+	if !m.Contains(teamName) || !m.IsPlayed() {
 		return 0.0
 	}
 
@@ -159,6 +272,14 @@ func (m *Match) GetOpponent(teamName string) (string, error) {
 }
 
 func (m *Match) ToString() string {
+	if !m.IsPlayed() {
+		return fmt.Sprintf("%s,,%s, (%s)", m.Home.Name, m.Away.Name, m.State)
+	}
+
+	if annotation := m.Annotation(); annotation != "" {
+		return fmt.Sprintf("%s,%d,%s,%d %s", m.Home.Name, m.Home.Score, m.Away.Name, m.Away.Score, annotation)
+	}
+
 	return fmt.Sprintf("%s,%d,%s,%d", m.Home.Name, m.Home.Score, m.Away.Name, m.Away.Score)
 }
 
@@ -1,6 +1,8 @@
 package match_test
 
 import (
+	"time"
+
 	"github.com/jedi-knights/rpi/pkg/match"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -783,4 +785,140 @@ var _ = Describe("Match", func() {
 			Expect((*pSubslice)[1]).To(Equal(matches[5]))
 		})
 	})
+
+	Describe("NewMatchFromFields", func() {
+		var date time.Time
+
+		BeforeEach(func() {
+			date = time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC)
+		})
+
+		It("should build a match from its fields", func() {
+			// Act
+			answer, err := match.NewMatchFromFields(date, " Team A ", "2", "Team B", "2 (OT)", match.Site{Neutral: true})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(answer.Date).To(Equal(date))
+			Expect(answer.Home.Name).To(Equal("Team A"))
+			Expect(answer.Away.Score).To(Equal(2))
+			Expect(answer.Decision).To(Equal(match.DecisionOvertime))
+			Expect(answer.Neutral).To(BeTrue())
+		})
+
+		DescribeTable("should describe the field that can't be parsed",
+			func(homeName, homeScore, awayName, awayScore, message string) {
+				// Act
+				answer, err := match.NewMatchFromFields(date, homeName, homeScore, awayName, awayScore, match.Site{})
+
+				// Assert
+				Expect(answer).To(BeNil())
+				Expect(err).To(MatchError(message))
+			},
+			Entry("empty home team", "", "1", "Team B", "0", "the home team name is empty"),
+			Entry("empty away team", "Team A", "1", " ", "0", "the away team name is empty"),
+			Entry("same team", "Team A", "1", "Team A", "0", "Team A can't play itself"),
+			Entry("home score", "Team A", "one", "Team B", "0", "invalid home score <one>"),
+			Entry("away score", "Team A", "1", "Team B", "zero", "invalid away score <zero>"),
+			Entry("decision", "Team A", "1", "Team B", "1 (XYZ)", "unknown decision <XYZ>"),
+			Entry("shootout", "Team A", "2", "Team B", "1 (4-3 PK)", "a shootout requires a level score"),
+		)
+	})
+
+	Describe("a fixture that hasn't been played", func() {
+		var fixture *match.Match
+
+		BeforeEach(func() {
+			fixture = match.NewMatchFromString("2023-05-09,A,,B,")
+			Expect(fixture).NotTo(BeNil())
+		})
+
+		It("should not be a draw", func() {
+			Expect(fixture.IsDraw()).To(BeFalse())
+		})
+
+		It("should have no winner or loser", func() {
+			Expect(fixture.IsWinner("A")).To(BeFalse())
+			Expect(fixture.IsWinner("B")).To(BeFalse())
+			Expect(fixture.IsLoser("A")).To(BeFalse())
+			Expect(fixture.IsLoser("B")).To(BeFalse())
+		})
+
+		It("should earn no credit", func() {
+			Expect(fixture.WinValue("A")).To(Equal(0.0))
+			Expect(fixture.WinValue("B")).To(Equal(0.0))
+		})
+	})
+
+	Describe("SetScore", func() {
+		It("should change the score and decision", func() {
+			// Arrange
+			m := match.NewMatchFromString("2023-09-01,Team A,2,Team B,1 (OT)")
+
+			// Act
+			err := m.SetScore("2-2 (4-3 PK)")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.ToString()).To(Equal("Team A,2,Team B,2 (4-3 PK)"))
+		})
+
+		It("should enter the result of a fixture decided by a shootout", func() {
+			// Arrange
+			m := match.NewMatchFromString("2023-09-01,Team A,,Team B,")
+
+			// Act
+			err := m.SetScore("1-1 (4-3 PK)")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.IsPlayed()).To(BeTrue())
+			Expect(m.IsDraw()).To(BeTrue())
+			Expect(m.Decision).To(Equal(match.DecisionShootout))
+		})
+
+		It("should clear the decision of a corrected score", func() {
+			// Arrange
+			m := match.NewMatchFromString("2023-09-01,Team A,2,Team B,1 (OT)")
+
+			// Act
+			err := m.SetScore("3-1")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Decision).To(Equal(match.DecisionRegulation))
+			Expect(m.Home.Score).To(Equal(3))
+		})
+
+		DescribeTable("should leave the match unchanged when the score can't be parsed",
+			func(score, message string) {
+				// Arrange
+				m := match.NewMatchFromString("2023-09-01,Team A,2,Team B,1")
+
+				// Act
+				err := m.SetScore(score)
+
+				// Assert
+				Expect(err).To(MatchError(message))
+				Expect(m.ToString()).To(Equal("Team A,2,Team B,1"))
+			},
+			Entry("no separator", "21", "invalid score <21>"),
+			Entry("home score", "two-1", "invalid home score <two>"),
+			Entry("away score", "3-one", "invalid away score <one>"),
+			Entry("shootout", "3-1 (PK)", "a shootout requires a level score"),
+		)
+	})
+
+	It("should clone a match", func() {
+		// Arrange
+		m := match.NewMatchFromString("2023-09-01,Team A,2,Team B,1")
+
+		// Act
+		clone := m.Clone()
+		clone.Home.Score = 5
+
+		// Assert
+		Expect(m.Home.Score).To(Equal(2))
+		Expect(clone.Away.Name).To(Equal("Team B"))
+	})
 })
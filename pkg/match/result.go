@@ -0,0 +1,30 @@
+package match
+
+// Result is the outcome of a match from the point of view of one of its teams.
+type Result int
+
+const (
+	ResultNone Result = iota
+	ResultWin
+	ResultLoss
+	ResultTie
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultWin:
+		return "W"
+	case ResultLoss:
+		return "L"
+	case ResultTie:
+		return "T"
+	}
+
+	return "-"
+}
+
+// ResultFor returns the outcome of the match for the specified team by its final score, or
+// ResultNone when the team isn't in the match.
+func (m *Match) ResultFor(teamName string) Result {
+	return m.ResultUnder(teamName, nil)
+}
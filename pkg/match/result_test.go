@@ -0,0 +1,56 @@
+package match_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Result", func() {
+	var myMatch *match.Match
+
+	BeforeEach(func() {
+		myMatch = match.NewBuilder().
+			BuildHomeName("Team A").
+			BuildHomeScore(2).
+			BuildAwayName("Team B").
+			BuildAwayScore(1).
+			GetInstance()
+	})
+
+	AfterEach(func() {
+		myMatch = nil
+	})
+
+	Describe("ResultFor", func() {
+		It("returns a win for the winner", func() {
+			Expect(myMatch.ResultFor("Team A")).To(Equal(match.ResultWin))
+		})
+
+		It("returns a loss for the loser", func() {
+			Expect(myMatch.ResultFor("Team B")).To(Equal(match.ResultLoss))
+		})
+
+		It("returns a tie for both teams when the match is a draw", func() {
+			// Arrange
+			myMatch.Away.Score = 2
+
+			// Assert
+			Expect(myMatch.ResultFor("Team A")).To(Equal(match.ResultTie))
+			Expect(myMatch.ResultFor("Team B")).To(Equal(match.ResultTie))
+		})
+
+		It("returns none for a team that isn't in the match", func() {
+			Expect(myMatch.ResultFor("Team C")).To(Equal(match.ResultNone))
+		})
+	})
+
+	Describe("String", func() {
+		It("returns the letter of the result", func() {
+			Expect(match.ResultWin.String()).To(Equal("W"))
+			Expect(match.ResultLoss.String()).To(Equal("L"))
+			Expect(match.ResultTie.String()).To(Equal("T"))
+			Expect(match.ResultNone.String()).To(Equal("-"))
+		})
+	})
+})
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Site is where a match was played.
+type Site struct {
+	// Neutral is true when the match was played at a neutral site rather than at the home team's venue.
+	Neutral bool
+
+	// Venue and City optionally name the place the match was played.
+	Venue string
+	City  string
+}
+
+// ParseSite parses the optional site columns that may follow the scores of a match: a location of
+// home or neutral (H or N), followed by an optional venue name and city.  Empty columns are allowed.
+func ParseSite(tokens []string) (Site, error) {
+	var site Site
+
+	if len(tokens) > 3 {
+		return site, fmt.Errorf("too many site columns")
+	}
+
+	if len(tokens) > 0 {
+		switch strings.ToLower(strings.TrimSpace(tokens[0])) {
+		case "", "h", "home":
+			site.Neutral = false
+		case "n", "neutral":
+			site.Neutral = true
+		default:
+			return site, fmt.Errorf("invalid location <%s>", tokens[0])
+		}
+	}
+
+	if len(tokens) > 1 {
+		site.Venue = strings.TrimSpace(tokens[1])
+	}
+
+	if len(tokens) > 2 {
+		site.City = strings.TrimSpace(tokens[2])
+	}
+
+	return site, nil
+}
+
+// ToString returns the site in the form of the optional match columns.
+func (s Site) ToString() string {
+	location := "H"
+	if s.Neutral {
+		location = "N"
+	}
+
+	if s.Venue == "" && s.City == "" {
+		return location
+	}
+
+	return fmt.Sprintf("%s,%s,%s", location, s.Venue, s.City)
+}
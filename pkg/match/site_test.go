@@ -0,0 +1,128 @@
+package match_test
+
+import (
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Site", func() {
+	Describe("ParseSite", func() {
+		It("returns the home team's venue when there are no site columns", func() {
+			// Act
+			site, err := match.ParseSite(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(site).To(Equal(match.Site{}))
+		})
+
+		It("parses a neutral site", func() {
+			// Act
+			site, err := match.ParseSite([]string{"N"})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(site.Neutral).To(BeTrue())
+		})
+
+		It("parses the location case-insensitively", func() {
+			// Act
+			home, homeErr := match.ParseSite([]string{"Home"})
+			neutral, neutralErr := match.ParseSite([]string{" neutral "})
+
+			// Assert
+			Expect(homeErr).NotTo(HaveOccurred())
+			Expect(home.Neutral).To(BeFalse())
+			Expect(neutralErr).NotTo(HaveOccurred())
+			Expect(neutral.Neutral).To(BeTrue())
+		})
+
+		It("parses the venue and city", func() {
+			// Act
+			site, err := match.ParseSite([]string{"N", "WakeMed Soccer Park", "Cary"})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(site).To(Equal(match.Site{Neutral: true, Venue: "WakeMed Soccer Park", City: "Cary"}))
+		})
+
+		It("returns an error for an invalid location", func() {
+			// Act
+			_, err := match.ParseSite([]string{"X"})
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid location <X>"))
+		})
+
+		It("returns an error for too many columns", func() {
+			// Act
+			_, err := match.ParseSite([]string{"N", "Venue", "City", "State"})
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ToString", func() {
+		It("returns only the location when there is no venue", func() {
+			Expect(match.Site{}.ToString()).To(Equal("H"))
+			Expect(match.Site{Neutral: true}.ToString()).To(Equal("N"))
+		})
+
+		It("returns the location, venue and city", func() {
+			// Arrange
+			site := match.Site{Neutral: true, Venue: "WakeMed Soccer Park", City: "Cary"}
+
+			// Act
+			answer := site.ToString()
+
+			// Assert
+			Expect(answer).To(Equal("N,WakeMed Soccer Park,Cary"))
+		})
+	})
+
+	Describe("NewMatchFromString", func() {
+		It("parses a match without site columns", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,0")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Date).To(Equal(time.Date(2023, 9, 19, 0, 0, 0, 0, time.UTC)))
+			Expect(myMatch.Site).To(Equal(match.Site{}))
+		})
+
+		It("parses a match at a neutral site", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,0,N")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Neutral).To(BeTrue())
+			Expect(myMatch.LocationOf("Team A")).To(Equal(match.LocationNeutral))
+		})
+
+		It("parses a match with a venue and city", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,0,H,Koskinen Stadium,Durham")
+
+			// Assert
+			Expect(myMatch).NotTo(BeNil())
+			Expect(myMatch.Neutral).To(BeFalse())
+			Expect(myMatch.Venue).To(Equal("Koskinen Stadium"))
+			Expect(myMatch.City).To(Equal("Durham"))
+		})
+
+		It("returns nil for an invalid location", func() {
+			// Act
+			myMatch := match.NewMatchFromString("2023-09-19,Team A,1,Team B,0,X")
+
+			// Assert
+			Expect(myMatch).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+)
+
+// State is whether a match has been played.  Only completed matches have a result; the others are
+// fixtures whose scores are meaningless.
+type State int
+
+const (
+	// StateCompleted is a match that has been played.  It is the zero value so that matches recorded
+	// before states existed are completed.
+	StateCompleted State = iota
+
+	// StateScheduled is a fixture that hasn't been played yet.
+	StateScheduled
+
+	// StatePostponed is a fixture that was moved and still has to be played.
+	StatePostponed
+
+	// StateCancelled is a fixture that won't be played.
+	StateCancelled
+)
+
+func (s State) String() string {
+	switch s {
+	case StateCompleted:
+		return "completed"
+	case StateScheduled:
+		return "scheduled"
+	case StatePostponed:
+		return "postponed"
+	case StateCancelled:
+		return "cancelled"
+	}
+
+	return "unknown"
+}
+
+// MarshalText encodes the state as its name.
+func (s State) MarshalText() ([]byte, error) {
+	if s < StateCompleted || s > StateCancelled {
+		return nil, fmt.Errorf("unknown state %d", s)
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a state from its name.
+func (s *State) UnmarshalText(text []byte) error {
+	state, err := ParseState(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = state
+
+	return nil
+}
+
+// ParseState parses the name of a state without regard to case.  It also accepts the common
+// abbreviations "final", "ppd" and "canceled".
+func ParseState(name string) (State, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "completed", "final":
+		return StateCompleted, nil
+	case "scheduled":
+		return StateScheduled, nil
+	case "postponed", "ppd":
+		return StatePostponed, nil
+	case "cancelled", "canceled":
+		return StateCancelled, nil
+	}
+
+	return StateCompleted, fmt.Errorf("unknown state <%s>", name)
+}
+
+// IsPlayed reports whether the match has been played and so has a result.
+func (m *Match) IsPlayed() bool {
+	return m.State == StateCompleted
+}
+
+// IsPending reports whether the match hasn't been played yet but still will be.
+func (m *Match) IsPending() bool {
+	return m.State == StateScheduled || m.State == StatePostponed
+}
@@ -0,0 +1,121 @@
+package match_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("State", func() {
+	Describe("ParseState", func() {
+		DescribeTable("parses a state",
+			func(name string, state match.State) {
+				// Act
+				s, err := match.ParseState(name)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(s).To(Equal(state))
+			},
+			Entry("completed", "completed", match.StateCompleted),
+			Entry("final", "Final", match.StateCompleted),
+			Entry("scheduled", "SCHEDULED", match.StateScheduled),
+			Entry("postponed", "postponed", match.StatePostponed),
+			Entry("postponed abbreviation", "PPD", match.StatePostponed),
+			Entry("cancelled", "cancelled", match.StateCancelled),
+			Entry("canceled", " canceled ", match.StateCancelled),
+		)
+
+		It("returns an error for an unknown state", func() {
+			// Act
+			_, err := match.ParseState("delayed")
+
+			// Assert
+			Expect(err).To(MatchError("unknown state <delayed>"))
+		})
+	})
+
+	Describe("fixtures", func() {
+		It("parses a match without scores as a scheduled fixture", func() {
+			// Act
+			m := match.NewMatchFromString("2023-10-01,Duke,,UNC,")
+
+			// Assert
+			Expect(m).NotTo(BeNil())
+			Expect(m.State).To(Equal(match.StateScheduled))
+			Expect(m.IsPlayed()).To(BeFalse())
+			Expect(m.IsPending()).To(BeTrue())
+			Expect(m.ToString()).To(Equal("Duke,,UNC, (scheduled)"))
+		})
+
+		It("doesn't parse a match with only one score", func() {
+			// Act
+			m := match.NewMatchFromString("2023-10-01,Duke,2,UNC,")
+
+			// Assert
+			Expect(m).To(BeNil())
+		})
+
+		It("has no result until it is played", func() {
+			// Arrange
+			m := match.NewMatchFromString("Duke,,UNC,")
+
+			// Act
+			result := m.ResultFor("Duke")
+
+			// Assert
+			Expect(result).To(Equal(match.ResultNone))
+			Expect(m.ResultUnder("UNC", match.DecisionRules{match.DecisionRegulation: match.CreditTie})).To(Equal(match.ResultNone))
+		})
+
+		It("is completed when its score is set", func() {
+			// Arrange
+			m := match.NewMatchFromString("Duke,,UNC,")
+
+			// Act
+			err := m.SetScore("2-1")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.State).To(Equal(match.StateCompleted))
+			Expect(m.ResultFor("Duke")).To(Equal(match.ResultWin))
+		})
+
+		It("isn't pending once it is cancelled", func() {
+			// Arrange
+			m := match.NewMatchFromString("Duke,,UNC,")
+
+			// Act
+			m.State = match.StateCancelled
+
+			// Assert
+			Expect(m.IsPlayed()).To(BeFalse())
+			Expect(m.IsPending()).To(BeFalse())
+		})
+	})
+
+	Describe("MarshalText", func() {
+		It("round-trips every state", func() {
+			for state := match.StateCompleted; state <= match.StateCancelled; state++ {
+				// Act
+				text, err := state.MarshalText()
+				Expect(err).NotTo(HaveOccurred())
+
+				var decoded match.State
+				err = decoded.UnmarshalText(text)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decoded).To(Equal(state))
+			}
+		})
+
+		It("returns an error for an unknown state", func() {
+			// Act
+			_, err := match.State(9).MarshalText()
+
+			// Assert
+			Expect(err).To(MatchError("unknown state 9"))
+		})
+	})
+})
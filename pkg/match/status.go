@@ -3,4 +3,7 @@ package match
 type Status struct {
 	Name  string
 	Score int
+
+	// Shootout is the team's score in a shootout that decided a level match.
+	Shootout int
 }
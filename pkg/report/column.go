@@ -0,0 +1,206 @@
+package report
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// Column is a column of a report.
+type Column int
+
+const (
+	ColumnRank Column = iota
+	ColumnTeam
+	ColumnRecord
+	ColumnWins
+	ColumnLosses
+	ColumnTies
+	ColumnWP
+	ColumnOWP
+	ColumnOOWP
+	ColumnRPI
+	ColumnBonus
+	ColumnPenalty
+	ColumnAdjustedRPI
+	ColumnConference
+	ColumnConferenceRecord
+	ColumnConferenceRPI
+	ColumnNonConferenceRecord
+	ColumnNonConferenceRPI
+)
+
+var (
+	// DefaultColumns is the columns of a report ranked by RPI.
+	DefaultColumns = []Column{ColumnRank, ColumnTeam, ColumnRecord, ColumnWP, ColumnOWP, ColumnOOWP, ColumnRPI}
+
+	// AdjustedColumns is the columns of a report ranked by adjusted RPI.
+	AdjustedColumns = []Column{ColumnRank, ColumnTeam, ColumnRecord, ColumnWP, ColumnOWP, ColumnOOWP, ColumnRPI, ColumnBonus, ColumnPenalty, ColumnAdjustedRPI}
+
+	// SplitColumns is the columns of a report with the conference and non-conference views.
+	SplitColumns = []Column{ColumnRank, ColumnTeam, ColumnConference, ColumnRecord, ColumnRPI, ColumnConferenceRecord, ColumnConferenceRPI, ColumnNonConferenceRecord, ColumnNonConferenceRPI}
+)
+
+// String returns the name of the column used for column selection and as the key in JSON.
+func (c Column) String() string {
+	switch c {
+	case ColumnRank:
+		return "rank"
+	case ColumnTeam:
+		return "team"
+	case ColumnRecord:
+		return "record"
+	case ColumnWins:
+		return "wins"
+	case ColumnLosses:
+		return "losses"
+	case ColumnTies:
+		return "ties"
+	case ColumnWP:
+		return "wp"
+	case ColumnOWP:
+		return "owp"
+	case ColumnOOWP:
+		return "oowp"
+	case ColumnRPI:
+		return "rpi"
+	case ColumnBonus:
+		return "bonus"
+	case ColumnPenalty:
+		return "penalty"
+	case ColumnAdjustedRPI:
+		return "arpi"
+	case ColumnConference:
+		return "conference"
+	case ColumnConferenceRecord:
+		return "conf-record"
+	case ColumnConferenceRPI:
+		return "conf-rpi"
+	case ColumnNonConferenceRecord:
+		return "nc-record"
+	case ColumnNonConferenceRPI:
+		return "nc-rpi"
+	}
+
+	return "unknown"
+}
+
+// Title returns the heading of the column.
+func (c Column) Title() string {
+	switch c {
+	case ColumnRank:
+		return "Rank"
+	case ColumnTeam:
+		return "Team"
+	case ColumnRecord:
+		return "Record"
+	case ColumnWins:
+		return "W"
+	case ColumnLosses:
+		return "L"
+	case ColumnTies:
+		return "T"
+	case ColumnWP:
+		return "WP"
+	case ColumnOWP:
+		return "OWP"
+	case ColumnOOWP:
+		return "OOWP"
+	case ColumnRPI:
+		return "RPI"
+	case ColumnBonus:
+		return "Bonus"
+	case ColumnPenalty:
+		return "Penalty"
+	case ColumnAdjustedRPI:
+		return "ARPI"
+	case ColumnConference:
+		return "Conference"
+	case ColumnConferenceRecord:
+		return "Conf Record"
+	case ColumnConferenceRPI:
+		return "Conf RPI"
+	case ColumnNonConferenceRecord:
+		return "NC Record"
+	case ColumnNonConferenceRPI:
+		return "NC RPI"
+	}
+
+	return "Unknown"
+}
+
+func (c Column) numeric() bool {
+	switch c {
+	case ColumnTeam, ColumnRecord, ColumnConference, ColumnConferenceRecord, ColumnNonConferenceRecord:
+		return false
+	}
+
+	return true
+}
+
+func (c Column) cell(row Row, precision int) cell {
+	switch c {
+	case ColumnRank:
+		return integerCell(row.Rank)
+	case ColumnTeam:
+		return textCell(row.Team)
+	case ColumnRecord:
+		return textCell(fmt.Sprintf("%d-%d-%d", row.Wins, row.Losses, row.Ties))
+	case ColumnWins:
+		return integerCell(row.Wins)
+	case ColumnLosses:
+		return integerCell(row.Losses)
+	case ColumnTies:
+		return integerCell(row.Ties)
+	case ColumnWP:
+		return decimalCell(row.WP, precision)
+	case ColumnOWP:
+		return decimalCell(row.OWP, precision)
+	case ColumnOOWP:
+		return decimalCell(row.OOWP, precision)
+	case ColumnRPI:
+		return decimalCell(row.RPI, precision)
+	case ColumnBonus:
+		return decimalCell(row.Bonus, precision)
+	case ColumnPenalty:
+		return decimalCell(row.Penalty, precision)
+	case ColumnAdjustedRPI:
+		return decimalCell(row.AdjustedRPI, precision)
+	}
+
+	return c.splitCell(row, precision)
+}
+
+// splitCell returns the cell of a conference column, which is empty for a team without a split rating
+// or without matches in the view.
+func (c Column) splitCell(row Row, precision int) cell {
+	var view *schedule.Rating
+	if row.Split != nil {
+		switch c {
+		case ColumnConference:
+			return textCell(row.Split.Conference)
+		case ColumnConferenceRecord, ColumnConferenceRPI:
+			view = row.Split.InConference
+		case ColumnNonConferenceRecord, ColumnNonConferenceRPI:
+			view = row.Split.NonConference
+		}
+	}
+
+	switch c {
+	case ColumnConferenceRecord, ColumnNonConferenceRecord:
+		if view == nil {
+			return textCell("-")
+		}
+
+		return textCell(fmt.Sprintf("%d-%d-%d", view.Wins, view.Losses, view.Ties))
+	case ColumnConferenceRPI, ColumnNonConferenceRPI:
+		if view == nil {
+			return decimalCell(math.NaN(), precision)
+		}
+
+		return decimalCell(view.RPI, precision)
+	}
+
+	return textCell("")
+}
@@ -0,0 +1,257 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+)
+
+// Format is a rendering of a report.
+type Format int
+
+const (
+	FormatCSV Format = iota
+	FormatJSON
+	FormatMarkdown
+	FormatHTML
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatCSV:
+		return "csv"
+	case FormatJSON:
+		return "json"
+	case FormatMarkdown:
+		return "markdown"
+	case FormatHTML:
+		return "html"
+	}
+
+	return "unknown"
+}
+
+// ParseFormat parses a format name such as "csv", "json", "markdown" or "html".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "csv":
+		return FormatCSV, nil
+	case "json":
+		return FormatJSON, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	}
+
+	return FormatCSV, fmt.Errorf("unknown format %s", name)
+}
+
+// Render writes the report in the specified format.
+func Render(w io.Writer, r *Report, format Format, opts Options) error {
+	switch format {
+	case FormatCSV:
+		return RenderCSV(w, r, opts)
+	case FormatJSON:
+		return RenderJSON(w, r, opts)
+	case FormatMarkdown:
+		return RenderMarkdown(w, r, opts)
+	case FormatHTML:
+		return RenderHTML(w, r, opts)
+	}
+
+	return fmt.Errorf("unknown format %d", format)
+}
+
+// RenderCSV writes the report as CSV with a header row of column titles.
+func RenderCSV(w io.Writer, r *Report, opts Options) error {
+	t := r.table(opts)
+	writer := csv.NewWriter(w)
+
+	header := make([]string, 0, len(t.columns))
+	for _, column := range t.columns {
+		header = append(header, column.Title())
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range t.rows {
+		record := make([]string, 0, len(row))
+		for _, c := range row {
+			record = append(record, c.text)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// RenderJSON writes the report as a JSON array with an object per team whose keys are the column
+// names in column order.  Elements are rounded to the precision and undefined elements are null.
+func RenderJSON(w io.Writer, r *Report, opts Options) error {
+	t := r.table(opts)
+
+	var buffer bytes.Buffer
+	buffer.WriteString("[")
+
+	for i, row := range t.rows {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n  {")
+
+		for j, c := range row {
+			if j > 0 {
+				buffer.WriteString(", ")
+			}
+
+			key, err := json.Marshal(t.columns[j].String())
+			if err != nil {
+				return err
+			}
+
+			value, err := c.json()
+			if err != nil {
+				return err
+			}
+
+			buffer.Write(key)
+			buffer.WriteString(": ")
+			buffer.Write(value)
+		}
+
+		buffer.WriteString("}")
+	}
+
+	if len(t.rows) > 0 {
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]\n")
+
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+func (c cell) json() ([]byte, error) {
+	switch {
+	case !c.numeric:
+		return json.Marshal(c.text)
+	case math.IsNaN(c.value):
+		return []byte("null"), nil
+	}
+
+	return []byte(c.text), nil
+}
+
+// RenderMarkdown writes the report as a Markdown table with numeric columns aligned to the right.
+func RenderMarkdown(w io.Writer, r *Report, opts Options) error {
+	t := r.table(opts)
+
+	var buffer bytes.Buffer
+
+	titles := make([]string, 0, len(t.columns))
+	alignments := make([]string, 0, len(t.columns))
+	for _, column := range t.columns {
+		titles = append(titles, column.Title())
+		if column.numeric() {
+			alignments = append(alignments, "---:")
+		} else {
+			alignments = append(alignments, "---")
+		}
+	}
+
+	writeMarkdownRow(&buffer, titles)
+	writeMarkdownRow(&buffer, alignments)
+
+	for _, row := range t.rows {
+		texts := make([]string, 0, len(row))
+		for _, c := range row {
+			texts = append(texts, markdownEscaper.Replace(c.text))
+		}
+
+		writeMarkdownRow(&buffer, texts)
+	}
+
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\\", `\\`)
+
+func writeMarkdownRow(buffer *bytes.Buffer, texts []string) {
+	buffer.WriteString("| ")
+	buffer.WriteString(strings.Join(texts, " | "))
+	buffer.WriteString(" |\n")
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { padding: 0.25em 0.75em; border-bottom: 1px solid #ddd; }
+th { text-align: left; background: #f4f4f4; }
+.number { text-align: right; font-variant-numeric: tabular-nums; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<thead>
+<tr>{{range .Headings}}<th{{if .Numeric}} class="number"{{end}}>{{.Text}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td{{if .Numeric}} class="number"{{end}}>{{.Text}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`))
+
+type htmlCell struct {
+	Text    string
+	Numeric bool
+}
+
+// RenderHTML writes the report as a self-contained HTML page.
+func RenderHTML(w io.Writer, r *Report, opts Options) error {
+	t := r.table(opts)
+
+	data := struct {
+		Title    string
+		Headings []htmlCell
+		Rows     [][]htmlCell
+	}{Title: opts.title()}
+
+	for _, column := range t.columns {
+		data.Headings = append(data.Headings, htmlCell{Text: column.Title(), Numeric: column.numeric()})
+	}
+
+	for _, row := range t.rows {
+		cells := make([]htmlCell, 0, len(row))
+		for _, c := range row {
+			cells = append(cells, htmlCell{Text: c.text, Numeric: c.numeric})
+		}
+
+		data.Rows = append(data.Rows, cells)
+	}
+
+	return htmlTemplate.Execute(w, data)
+}
@@ -0,0 +1,200 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// DefaultPrecision is the number of decimal places used when the precision is negative.
+const DefaultPrecision = 4
+
+// Row is a single team's line in a report.
+type Row struct {
+	Rank int
+	*schedule.AdjustedRating
+
+	// Split is the team's conference and non-conference ratings, which is nil unless they were added.
+	Split *schedule.SplitRating
+}
+
+// Report is a ranking of teams ready to be rendered.
+type Report struct {
+	Rows []Row
+
+	// Adjusted is true when the rows are ranked by adjusted RPI and have bonuses and penalties.
+	Adjusted bool
+
+	// Splits is true when the rows have conference and non-conference ratings.
+	Splits bool
+}
+
+// New returns a report that ranks the teams by RPI.
+func New(ratings schedule.Ratings) *Report {
+	ranked := slices.Clone(ratings)
+	ranked.SortByRPI()
+
+	report := &Report{Rows: make([]Row, 0, len(ranked))}
+	for i, rating := range ranked {
+		report.Rows = append(report.Rows, Row{
+			Rank:           i + 1,
+			AdjustedRating: &schedule.AdjustedRating{Rating: rating, Rank: i + 1, AdjustedRPI: rating.RPI},
+		})
+	}
+
+	return report
+}
+
+// NewAdjusted returns a report that ranks the teams by adjusted RPI.
+func NewAdjusted(ratings schedule.AdjustedRatings) *Report {
+	ranked := slices.Clone(ratings)
+	ranked.SortByAdjustedRPI()
+
+	report := &Report{Rows: make([]Row, 0, len(ranked)), Adjusted: true}
+	for i, rating := range ranked {
+		report.Rows = append(report.Rows, Row{Rank: i + 1, AdjustedRating: rating})
+	}
+
+	return report
+}
+
+// AddSplits adds each team's conference and non-conference ratings to its row.
+func (r *Report) AddSplits(splits schedule.SplitRatings) {
+	for i := range r.Rows {
+		r.Rows[i].Split = splits.Find(r.Rows[i].Team)
+	}
+
+	r.Splits = true
+}
+
+// Options controls how a report is rendered.
+type Options struct {
+	// Title names the report in formats that have a title.
+	Title string
+
+	// Precision is the number of decimal places of each element, so zero prints whole numbers.
+	// DefaultPrecision is used when it is negative.
+	Precision int
+
+	// Top limits the report to the top N teams.  Every team is included when it is zero.
+	Top int
+
+	// Columns is the columns to include, in order.  The default columns are used when it is empty.
+	Columns []Column
+}
+
+func (o Options) precision() int {
+	if o.Precision < 0 {
+		return DefaultPrecision
+	}
+
+	return o.Precision
+}
+
+func (o Options) title() string {
+	if o.Title == "" {
+		return "RPI Rankings"
+	}
+
+	return o.Title
+}
+
+func (o Options) columns(r *Report) []Column {
+	if len(o.Columns) > 0 {
+		return o.Columns
+	}
+
+	switch {
+	case r.Splits && r.Adjusted:
+		return append(slices.Clone(SplitColumns), ColumnAdjustedRPI)
+	case r.Splits:
+		return SplitColumns
+	case r.Adjusted:
+		return AdjustedColumns
+	}
+
+	return DefaultColumns
+}
+
+func (o Options) rows(r *Report) []Row {
+	if o.Top > 0 && o.Top < len(r.Rows) {
+		return r.Rows[:o.Top]
+	}
+
+	return r.Rows
+}
+
+// table is the report reduced to the cells of the selected columns.
+type table struct {
+	columns []Column
+	rows    [][]cell
+}
+
+// cell is a single value of a table.  Numeric cells have a value, which is NaN when it is undefined.
+type cell struct {
+	text    string
+	value   float64
+	numeric bool
+}
+
+func (r *Report) table(opts Options) table {
+	t := table{columns: opts.columns(r)}
+
+	for _, row := range opts.rows(r) {
+		cells := make([]cell, 0, len(t.columns))
+		for _, column := range t.columns {
+			cells = append(cells, column.cell(row, opts.precision()))
+		}
+
+		t.rows = append(t.rows, cells)
+	}
+
+	return t
+}
+
+func integerCell(value int) cell {
+	return cell{text: strconv.Itoa(value), value: float64(value), numeric: true}
+}
+
+func decimalCell(value float64, precision int) cell {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return cell{text: "NaN", value: math.NaN(), numeric: true}
+	}
+
+	return cell{text: strconv.FormatFloat(value, 'f', precision, 64), value: value, numeric: true}
+}
+
+func textCell(text string) cell {
+	return cell{text: text}
+}
+
+// ParseColumns parses a comma separated list of column names such as "rank,team,rpi".
+func ParseColumns(names string) ([]Column, error) {
+	var columns []Column
+
+	for _, name := range strings.Split(names, ",") {
+		column, err := ParseColumn(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// ParseColumn parses a column name such as "rank" or "rpi".
+func ParseColumn(name string) (Column, error) {
+	for column := ColumnRank; column <= ColumnNonConferenceRPI; column++ {
+		if strings.EqualFold(name, column.String()) {
+			return column, nil
+		}
+	}
+
+	return ColumnRank, fmt.Errorf("unknown column %s", name)
+}
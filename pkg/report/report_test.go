@@ -0,0 +1,233 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/report"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Report", func() {
+	var r *report.Report
+	var buffer *bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		r = report.New(schedule.Ratings{
+			{Team: "Kansas", Wins: 2, Losses: 1, WP: 0.6667, OWP: 0.5, OOWP: 0.5, RPI: 0.54166666},
+			{Team: "Duke <Blue Devils>", Wins: 1, Losses: 1, Ties: 1, WP: 0.5, OWP: 0.4, OOWP: math.NaN(), RPI: math.NaN()},
+			{Team: "UConn", Wins: 3, Losses: 1, WP: 0.75, OWP: 0.75, OOWP: 0.513888, RPI: 0.69097222},
+		})
+	})
+
+	It("should rank the teams by RPI", func() {
+		Expect(r.Rows).To(HaveLen(3))
+		Expect(r.Rows[0].Team).To(Equal("UConn"))
+		Expect(r.Rows[0].Rank).To(Equal(1))
+		Expect(r.Rows[2].Team).To(Equal("Duke <Blue Devils>"))
+	})
+
+	It("should rank adjusted ratings by adjusted RPI", func() {
+		// Arrange
+		adjusted := report.NewAdjusted(schedule.AdjustedRatings{
+			{Rating: &schedule.Rating{Team: "UConn", RPI: 0.69}, AdjustedRPI: 0.68},
+			{Rating: &schedule.Rating{Team: "Kansas", RPI: 0.54}, Bonus: 0.2, AdjustedRPI: 0.74},
+		})
+
+		// Act
+		Expect(report.RenderCSV(buffer, adjusted, report.Options{Precision: report.DefaultPrecision})).To(Succeed())
+
+		// Assert
+		Expect(adjusted.Rows[0].Team).To(Equal("Kansas"))
+		Expect(buffer.String()).To(HavePrefix("Rank,Team,Record,WP,OWP,OOWP,RPI,Bonus,Penalty,ARPI\n"))
+	})
+
+	It("should show the conference and non-conference views beside the overall RPI", func() {
+		// Arrange
+		r.AddSplits(schedule.SplitRatings{
+			{
+				Team:          "UConn",
+				Conference:    "Big East",
+				InConference:  &schedule.Rating{Wins: 2, Losses: 1, RPI: 0.6},
+				NonConference: &schedule.Rating{Wins: 1, RPI: 0.8},
+			},
+			{Team: "Kansas", Conference: "Big 12", NonConference: &schedule.Rating{Wins: 2, Losses: 1, RPI: 0.5}},
+		})
+
+		// Act
+		err := report.RenderCSV(buffer, r, report.Options{Precision: 2})
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+
+		records, err := csv.NewReader(buffer).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([][]string{
+			{"Rank", "Team", "Conference", "Record", "RPI", "Conf Record", "Conf RPI", "NC Record", "NC RPI"},
+			{"1", "UConn", "Big East", "3-1-0", "0.69", "2-1-0", "0.60", "1-0-0", "0.80"},
+			{"2", "Kansas", "Big 12", "2-1-0", "0.54", "-", "NaN", "2-1-0", "0.50"},
+			{"3", "Duke <Blue Devils>", "", "1-1-1", "NaN", "-", "NaN", "-", "NaN"},
+		}))
+	})
+
+	It("should parse the conference column names", func() {
+		// Act
+		columns, err := report.ParseColumns("team,conference,conf-rpi,nc-record")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal([]report.Column{report.ColumnTeam, report.ColumnConference, report.ColumnConferenceRPI, report.ColumnNonConferenceRecord}))
+	})
+
+	Describe("RenderCSV", func() {
+		It("should write a header and a record per team", func() {
+			// Act
+			err := report.RenderCSV(buffer, r, report.Options{Precision: report.DefaultPrecision})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			records, err := csv.NewReader(buffer).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal([][]string{
+				{"Rank", "Team", "Record", "WP", "OWP", "OOWP", "RPI"},
+				{"1", "UConn", "3-1-0", "0.7500", "0.7500", "0.5139", "0.6910"},
+				{"2", "Kansas", "2-1-0", "0.6667", "0.5000", "0.5000", "0.5417"},
+				{"3", "Duke <Blue Devils>", "1-1-1", "0.5000", "0.4000", "NaN", "NaN"},
+			}))
+		})
+
+		It("should apply the precision, cutoff and columns", func() {
+			// Arrange
+			opts := report.Options{
+				Precision: 2,
+				Top:       2,
+				Columns:   []report.Column{report.ColumnTeam, report.ColumnWins, report.ColumnRPI},
+			}
+
+			// Act
+			err := report.RenderCSV(buffer, r, opts)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("Team,W,RPI\nUConn,3,0.69\nKansas,2,0.54\n"))
+		})
+	})
+
+	Describe("RenderJSON", func() {
+		It("should write an object per team with the columns in order", func() {
+			// Act
+			err := report.RenderJSON(buffer, r, report.Options{Precision: report.DefaultPrecision, Columns: []report.Column{report.ColumnRank, report.ColumnTeam, report.ColumnRPI}})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(ContainSubstring(`{"rank": 1, "team": "UConn", "rpi": 0.6910}`))
+
+			var rows []map[string]any
+			Expect(json.Unmarshal(buffer.Bytes(), &rows)).To(Succeed())
+			Expect(rows).To(HaveLen(3))
+			Expect(rows[2]["team"]).To(Equal("Duke <Blue Devils>"))
+			Expect(rows[2]["rpi"]).To(BeNil())
+		})
+
+		DescribeTable("should round to the precision",
+			func(precision int, expected string) {
+				// Act
+				err := report.RenderJSON(buffer, r, report.Options{Precision: precision, Columns: []report.Column{report.ColumnTeam, report.ColumnRPI}})
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buffer.String()).To(ContainSubstring(expected))
+			},
+			Entry("whole numbers", 0, `{"team": "UConn", "rpi": 1}`),
+			Entry("the default when it is negative", -1, `{"team": "UConn", "rpi": 0.6910}`),
+		)
+
+		It("should write an empty array for an empty report", func() {
+			// Act
+			err := report.RenderJSON(buffer, report.New(nil), report.Options{})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("[]\n"))
+		})
+	})
+
+	Describe("RenderMarkdown", func() {
+		It("should write a table with numeric columns aligned to the right", func() {
+			// Act
+			err := report.RenderMarkdown(buffer, r, report.Options{Top: 1, Precision: report.DefaultPrecision})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("| Rank | Team | Record | WP | OWP | OOWP | RPI |\n" +
+				"| ---: | --- | --- | ---: | ---: | ---: | ---: |\n" +
+				"| 1 | UConn | 3-1-0 | 0.7500 | 0.7500 | 0.5139 | 0.6910 |\n"))
+		})
+	})
+
+	Describe("RenderHTML", func() {
+		It("should write a self-contained page", func() {
+			// Act
+			err := report.RenderHTML(buffer, r, report.Options{Title: "Week 5", Precision: report.DefaultPrecision})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			page := buffer.String()
+			Expect(page).To(HavePrefix("<!DOCTYPE html>"))
+			Expect(page).To(ContainSubstring("<title>Week 5</title>"))
+			Expect(page).To(ContainSubstring("<style>"))
+			Expect(page).To(ContainSubstring("Duke &lt;Blue Devils&gt;"))
+			Expect(page).To(ContainSubstring(`<td class="number">0.6910</td>`))
+			Expect(page).NotTo(ContainSubstring("<script"))
+		})
+	})
+
+	Describe("ParseColumns", func() {
+		It("should parse a list of column names", func() {
+			// Act
+			columns, err := report.ParseColumns("rank, Team,ARPI")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal([]report.Column{report.ColumnRank, report.ColumnTeam, report.ColumnAdjustedRPI}))
+		})
+
+		It("should reject an unknown column", func() {
+			// Act
+			_, err := report.ParseColumns("rank,sos")
+
+			// Assert
+			Expect(err).To(MatchError("unknown column sos"))
+		})
+	})
+
+	Describe("Render", func() {
+		It("should render the named format", func() {
+			// Arrange
+			format, err := report.ParseFormat("md")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			err = report.Render(buffer, r, format, report.Options{Precision: report.DefaultPrecision})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(HavePrefix("| Rank |"))
+		})
+
+		It("should reject an unknown format", func() {
+			// Act
+			_, err := report.ParseFormat("pdf")
+
+			// Assert
+			Expect(err).To(MatchError("unknown format pdf"))
+		})
+	})
+})
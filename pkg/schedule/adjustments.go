@@ -0,0 +1,226 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Adjustment awards points for a result against an opponent in a tier of the unadjusted ranking.
+// Bonuses are positive and penalties are negative.
+type Adjustment struct {
+	// Result is the result of the team being rated that earns the adjustment.
+	Result Result
+
+	// MinRank and MaxRank are the inclusive range of the opponent's unadjusted rank.  Ranks are
+	// counted from the bottom of the ranking when FromBottom is true, so that 1 is the lowest rated team.
+	MinRank    int
+	MaxRank    int
+	FromBottom bool
+
+	// Home, Away and Neutral are the points awarded according to where the rated team played.
+	Home    float64
+	Away    float64
+	Neutral float64
+}
+
+// AdjustmentTable is the set of bonus and penalty adjustments applied to the RPI.  Every adjustment
+// that matches a result is applied.
+type AdjustmentTable []Adjustment
+
+// WomensSoccerAdjustments is the bonus and penalty table used for Division I women's soccer.  Wins
+// and ties against the top 80 teams earn bonuses, and losses and ties against the bottom 80 teams
+// earn penalties, with road results rewarded most and home results penalized most.
+var WomensSoccerAdjustments = AdjustmentTable{
+	{Result: ResultWin, MinRank: 1, MaxRank: 40, Home: 0.0028, Neutral: 0.0030, Away: 0.0032},
+	{Result: ResultTie, MinRank: 1, MaxRank: 40, Home: 0.0012, Neutral: 0.0014, Away: 0.0016},
+	{Result: ResultWin, MinRank: 41, MaxRank: 80, Home: 0.0014, Neutral: 0.0016, Away: 0.0018},
+	{Result: ResultTie, MinRank: 41, MaxRank: 80, Home: 0.0002, Neutral: 0.0004, Away: 0.0006},
+	{Result: ResultLoss, MinRank: 1, MaxRank: 40, FromBottom: true, Home: -0.0032, Neutral: -0.0030, Away: -0.0028},
+	{Result: ResultTie, MinRank: 1, MaxRank: 40, FromBottom: true, Home: -0.0016, Neutral: -0.0014, Away: -0.0012},
+	{Result: ResultLoss, MinRank: 41, MaxRank: 80, FromBottom: true, Home: -0.0018, Neutral: -0.0016, Away: -0.0014},
+	{Result: ResultTie, MinRank: 41, MaxRank: 80, FromBottom: true, Home: -0.0006, Neutral: -0.0004, Away: -0.0002},
+}
+
+// Validate checks that every adjustment in the table has a result and a usable rank range.
+func (t AdjustmentTable) Validate() error {
+	for i, adjustment := range t {
+		if adjustment.Result == ResultNone {
+			return fmt.Errorf("adjustment %d has no result", i+1)
+		}
+
+		if adjustment.MinRank < 1 || adjustment.MaxRank < adjustment.MinRank {
+			return fmt.Errorf("adjustment %d has an invalid rank range %d-%d", i+1, adjustment.MinRank, adjustment.MaxRank)
+		}
+	}
+
+	return nil
+}
+
+func (a Adjustment) applies(result Result, rank, rankFromBottom int) bool {
+	if a.Result != result {
+		return false
+	}
+
+	if a.FromBottom {
+		rank = rankFromBottom
+	}
+
+	return rank >= a.MinRank && rank <= a.MaxRank
+}
+
+func (a Adjustment) points(location Location) float64 {
+	switch location {
+	case LocationHome:
+		return a.Home
+	case LocationAway:
+		return a.Away
+	}
+
+	return a.Neutral
+}
+
+// AdjustmentItem is a single bonus or penalty earned in a match.
+type AdjustmentItem struct {
+	Match        *Match
+	Opponent     string
+	OpponentRank int
+	Result       Result
+	Location     Location
+	Points       float64
+}
+
+// AdjustedRating is a team's unadjusted rating together with its bonus and penalty adjustments.
+type AdjustedRating struct {
+	*Rating
+
+	// Rank is the team's rank by unadjusted RPI.
+	Rank int
+
+	// Bonus is the sum of the positive adjustments and Penalty is the sum of the negative ones, so
+	// AdjustedRPI is RPI + Bonus + Penalty, summed exactly and rounded once.
+	Bonus       float64
+	Penalty     float64
+	AdjustedRPI float64
+	Items       []AdjustmentItem
+}
+
+// AdjustedRatings is the collection of adjusted ratings for every team in a schedule.
+type AdjustedRatings []*AdjustedRating
+
+// Find returns the adjusted rating for the specified team or nil if the team has no rating.
+func (r AdjustedRatings) Find(teamName string) *AdjustedRating {
+	for _, rating := range r {
+		if rating.Team == teamName {
+			return rating
+		}
+	}
+
+	return nil
+}
+
+// SortByAdjustedRPI orders the ratings from the highest adjusted RPI to the lowest.  Equal ratings
+// are ordered by team name and ratings with an undefined RPI are placed last.
+func (r AdjustedRatings) SortByAdjustedRPI() {
+	sortByValue(r, func(rating *AdjustedRating) (string, float64) {
+		return rating.Team, rating.AdjustedRPI
+	})
+}
+
+// CalculateAdjusted calculates every team's RPI and then applies the bonuses and penalties in the
+// table according to each opponent's rank by unadjusted RPI.
+func (s *Schedule) CalculateAdjusted(table AdjustmentTable) (AdjustedRatings, error) {
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := s.snapshot()
+	exactRatings := v.allExact()
+
+	ranked := slices.Clone(exactRatings)
+	ranked.SortByRPI()
+
+	ranks := make(map[string]int, len(ranked))
+	for i, rating := range ranked {
+		ranks[rating.Team] = i + 1
+	}
+
+	adjusted := make(AdjustedRatings, 0, len(exactRatings))
+	byTeam := make(map[string]*AdjustedRating, len(exactRatings))
+	for _, rating := range exactRatings {
+		ar := &AdjustedRating{Rating: rating.Rating(), Rank: ranks[rating.Team]}
+		adjusted = append(adjusted, ar)
+		byTeam[rating.Team] = ar
+	}
+
+	for _, m := range v.ratedMatches() {
+		for _, teamName := range []string{m.Home.Name, m.Away.Name} {
+			opponentName, err := m.GetOpponent(teamName)
+			if err != nil {
+				return nil, err
+			}
+
+			result := m.ResultUnder(teamName, v.formula.Decisions)
+			if result == ResultNone {
+				continue
+			}
+
+			byTeam[teamName].adjust(table, m, result, opponentName, ranks[opponentName], len(ranked)-ranks[opponentName]+1)
+		}
+	}
+
+	for i, ar := range adjusted {
+		ar.total(exactRatings[i].RPI)
+	}
+
+	return adjusted, nil
+}
+
+// total sums the rating's adjustments and adds them to its RPI exactly, converting each total to the
+// nearest float64 once so that adjusted ratings tie exactly when their exact values do.
+func (r *AdjustedRating) total(rpi Element) {
+	bonus, penalty := new(big.Rat), new(big.Rat)
+	for _, item := range r.Items {
+		if item.Points > 0 {
+			bonus.Add(bonus, exact(item.Points))
+		} else {
+			penalty.Add(penalty, exact(item.Points))
+		}
+	}
+
+	r.Bonus, _ = bonus.Float64()
+	r.Penalty, _ = penalty.Float64()
+
+	if !rpi.IsDefined() {
+		r.AdjustedRPI = math.NaN()
+		return
+	}
+
+	sum := rpi.Rat()
+	sum.Add(sum, bonus)
+	sum.Add(sum, penalty)
+	r.AdjustedRPI, _ = sum.Float64()
+}
+
+func (r *AdjustedRating) adjust(table AdjustmentTable, m *Match, result Result, opponentName string, rank, rankFromBottom int) {
+	location := m.LocationOf(r.Team)
+
+	for _, adjustment := range table {
+		if !adjustment.applies(result, rank, rankFromBottom) {
+			continue
+		}
+
+		points := adjustment.points(location)
+		r.Items = append(r.Items, AdjustmentItem{
+			Match:        m,
+			Opponent:     opponentName,
+			OpponentRank: rank,
+			Result:       result,
+			Location:     location,
+			Points:       points,
+		})
+	}
+}
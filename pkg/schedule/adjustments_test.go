@@ -0,0 +1,161 @@
+package schedule_test
+
+import (
+	"math/big"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Adjustments", func() {
+	var pSchedule *schedule.Schedule
+	var table schedule.AdjustmentTable
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")
+		pSchedule.AddMatchFromString("2023-11-10,UConn,82,Duke,68")
+		pSchedule.AddMatchFromString("2023-11-14,Wisconsin,71,UConn,72")
+		pSchedule.AddMatchFromString("2023-11-20,Kansas,69,UConn,62")
+		pSchedule.AddMatchFromString("2023-11-24,Duke,81,Wisconsin,70,N")
+		pSchedule.AddMatchFromString("2023-11-28,Wisconsin,52,Kansas,62")
+
+		// The unadjusted ranking is UConn, Kansas, Duke, Wisconsin.
+		table = schedule.AdjustmentTable{
+			{Result: match.ResultWin, MinRank: 1, MaxRank: 1, Home: 0.01, Away: 0.02, Neutral: 0.015},
+			{Result: match.ResultLoss, MinRank: 1, MaxRank: 2, FromBottom: true, Home: -0.02, Away: -0.01, Neutral: -0.005},
+		}
+	})
+
+	AfterEach(func() {
+		pSchedule = nil
+	})
+
+	Describe("CalculateAdjusted", func() {
+		It("should report the unadjusted rating and rank", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAdjusted(table)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(4))
+
+			uconn := ratings.Find("UConn")
+			Expect(uconn.Rank).To(Equal(1))
+			Expect(uconn.RPI).To(BeNumerically("~", 0.6910, 0.0001))
+			Expect(uconn.Bonus).To(Equal(0.0))
+			Expect(uconn.Penalty).To(Equal(0.0))
+			Expect(uconn.AdjustedRPI).To(Equal(uconn.RPI))
+			Expect(uconn.Items).To(BeEmpty())
+		})
+
+		It("should award a bonus for a win against a top ranked opponent", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAdjusted(table)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			kansas := ratings.Find("Kansas")
+			Expect(kansas.Rank).To(Equal(2))
+			Expect(kansas.Bonus).To(BeNumerically("~", 0.01, 1e-12))
+			Expect(kansas.Penalty).To(Equal(0.0))
+			Expect(kansas.AdjustedRPI).To(BeNumerically("~", kansas.RPI+0.01, 1e-12))
+			Expect(kansas.Items).To(HaveLen(1))
+			Expect(kansas.Items[0].Opponent).To(Equal("UConn"))
+			Expect(kansas.Items[0].OpponentRank).To(Equal(1))
+			Expect(kansas.Items[0].Result).To(Equal(match.ResultWin))
+			Expect(kansas.Items[0].Location).To(Equal(match.LocationHome))
+			Expect(kansas.Items[0].Match.ToString()).To(Equal("Kansas,69,UConn,62"))
+		})
+
+		It("should add the adjustments to the exact RPI before converting it", func() {
+			// Arrange
+			exact, err := pSchedule.CalculateExact("Kansas")
+			Expect(err).NotTo(HaveOccurred())
+			expected, _ := new(big.Rat).Add(exact.RPI.Rat(), big.NewRat(1, 100)).Float64()
+
+			// Act
+			ratings, err := pSchedule.CalculateAdjusted(table)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings.Find("Kansas").Bonus).To(Equal(0.01))
+			Expect(ratings.Find("Kansas").AdjustedRPI).To(Equal(expected))
+		})
+
+		It("should apply a penalty for a loss against a bottom ranked opponent", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAdjusted(table)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			wisconsin := ratings.Find("Wisconsin")
+			Expect(wisconsin.Rank).To(Equal(4))
+			Expect(wisconsin.Bonus).To(Equal(0.0))
+			Expect(wisconsin.Penalty).To(BeNumerically("~", -0.005, 1e-12))
+			Expect(wisconsin.AdjustedRPI).To(BeNumerically("~", wisconsin.RPI-0.005, 1e-12))
+			Expect(wisconsin.Items).To(HaveLen(1))
+			Expect(wisconsin.Items[0].Opponent).To(Equal("Duke"))
+			Expect(wisconsin.Items[0].Location).To(Equal(match.LocationNeutral))
+		})
+
+		It("should reject an invalid table", func() {
+			// Arrange
+			table = schedule.AdjustmentTable{{Result: match.ResultWin, MinRank: 10, MaxRank: 5}}
+
+			// Act
+			_, err := pSchedule.CalculateAdjusted(table)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("adjustment 1 has an invalid rank range 10-5"))
+		})
+
+		It("should not adjust anything with an empty table", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAdjusted(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			for _, rating := range ratings {
+				Expect(rating.AdjustedRPI).To(Equal(rating.RPI))
+			}
+		})
+	})
+
+	Describe("SortByAdjustedRPI", func() {
+		It("should order the ratings by adjusted RPI", func() {
+			// Arrange
+			table = schedule.AdjustmentTable{
+				{Result: match.ResultWin, MinRank: 1, MaxRank: 1, Home: 0.5, Away: 0.5, Neutral: 0.5},
+			}
+			ratings, err := pSchedule.CalculateAdjusted(table)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings.SortByAdjustedRPI()
+
+			// Assert
+			Expect(ratings[0].Team).To(Equal("Kansas"))
+			Expect(ratings[0].Rank).To(Equal(2))
+			Expect(ratings[1].Team).To(Equal("UConn"))
+		})
+	})
+
+	Describe("WomensSoccerAdjustments", func() {
+		It("should be a valid table", func() {
+			Expect(schedule.WomensSoccerAdjustments.Validate()).To(Succeed())
+		})
+
+		It("should reward road wins more than home wins", func() {
+			for _, adjustment := range schedule.WomensSoccerAdjustments {
+				Expect(adjustment.Away).To(BeNumerically(">", adjustment.Home))
+			}
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package schedule_test
+
+import (
+	"sync"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Concurrency", func() {
+	const workers = 8
+
+	var pSchedule *schedule.Schedule
+	var additions []*match.Match
+
+	BeforeEach(func() {
+		pSchedule = generateSeason(1, 40, 400)
+		additions = generateSeason(2, 40, 400).GetMatches()
+		for _, m := range additions {
+			m.ID = ""
+		}
+	})
+
+	// addAll adds the matches from several goroutines while run is called repeatedly from several others.
+	addAll := func(run func()) {
+		var readers sync.WaitGroup
+		done := make(chan struct{})
+
+		var writers sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			writers.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer writers.Done()
+
+				for j := i; j < len(additions); j += workers {
+					Expect(pSchedule.AddMatch(additions[j])).To(Succeed())
+				}
+			}(i)
+		}
+
+		for i := 0; i < workers; i++ {
+			readers.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer readers.Done()
+
+				for {
+					select {
+					case <-done:
+						return
+					default:
+						run()
+					}
+				}
+			}()
+		}
+
+		writers.Wait()
+		close(done)
+		readers.Wait()
+	}
+
+	It("should add matches while ratings are calculated", func() {
+		// Act
+		addAll(func() {
+			_, err := pSchedule.CalculateRPI("Team 001")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = pSchedule.GetRecord("Team 002")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// Assert
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(800))
+
+		expected := schedule.NewSchedule()
+		for _, m := range pSchedule.GetMatches() {
+			Expect(expected.AddMatch(m)).To(Succeed())
+		}
+
+		rpi, err := pSchedule.CalculateRPI("Team 001")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expected.CalculateRPI("Team 001")).To(Equal(rpi))
+	})
+
+	It("should rate a consistent snapshot of the matches", func() {
+		// Act
+		addAll(func() {
+			ratings, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			var wins, losses int
+			for _, rating := range ratings {
+				wins += rating.Wins
+				losses += rating.Losses
+			}
+			Expect(wins).To(Equal(losses))
+		})
+	})
+
+	It("should remove matches while adjusted ratings are calculated", func() {
+		// Arrange
+		removals := pSchedule.GetMatches()[:200]
+
+		// Act
+		addAll(func() {
+			for _, m := range removals {
+				_ = pSchedule.RemoveMatch(m)
+			}
+
+			_, err := pSchedule.CalculateAdjusted(schedule.WomensSoccerAdjustments)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		// Assert
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(600))
+	})
+})
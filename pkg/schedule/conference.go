@@ -0,0 +1,263 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// View is the part of a team's schedule a rating is calculated over.
+type View int
+
+const (
+	// ViewAll is every rated match.
+	ViewAll View = iota
+	// ViewConference is the matches between two teams of the same conference.
+	ViewConference
+	// ViewNonConference is the matches between teams of different conferences, or involving a team
+	// without one.
+	ViewNonConference
+)
+
+func (v View) String() string {
+	switch v {
+	case ViewAll:
+		return "all"
+	case ViewConference:
+		return "conference"
+	case ViewNonConference:
+		return "non-conference"
+	}
+
+	return "unknown"
+}
+
+// CalculateView calculates the WP, OWP, OOWP and RPI of every eligible team over the matches in the
+// view.  A team's WP and its opponents are taken from its matches in the view, but its opponents'
+// winning percentages and their opponents' are taken from every rated match, as the NCAA does for its
+// non-conference RPI.  Teams without a match in the view aren't rated.  The conference views need a
+// registry that assigns teams to conferences.
+func (s *Schedule) CalculateView(view View) (Ratings, error) {
+	v := s.snapshot()
+	if view != ViewAll && v.registry == nil {
+		return nil, fmt.Errorf("the %s view requires a registry", view)
+	}
+
+	return v.view(view), nil
+}
+
+// view calculates the ratings of every eligible team over the matches in the view.
+func (v *snapshot) view(view View) Ratings {
+	if view == ViewAll {
+		return v.all()
+	}
+
+	full := v.tally()
+	owps := full.owps(full.teams)
+	partial := newTally(v.viewMatches(view), v.formula)
+
+	ratings := make(Ratings, 0, len(partial.teams))
+	for _, teamName := range partial.teams {
+		ratings = append(ratings, full.viewRating(partial, teamName, owps).Rating())
+	}
+
+	return ratings
+}
+
+// viewMatches returns the rated matches in the view.
+func (v *snapshot) viewMatches(view View) []*Match {
+	var matches []*Match
+	for _, match := range v.ratedMatches() {
+		if v.isConferenceMatch(match) == (view == ViewConference) {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// isConferenceMatch reports whether both teams of the match belong to the same conference.
+func (v *snapshot) isConferenceMatch(match *Match) bool {
+	conference := v.registry.Conference(match.Home.Name)
+	return conference != "" && conference == v.registry.Conference(match.Away.Name)
+}
+
+// viewRating returns the exact rating of a team over the matches of the partial tally, looking up its
+// opponents' winning percentages in the full tally and their opponents' in owps.
+func (t *tally) viewRating(partial *tally, teamName string, owps map[string]*big.Rat) *ExactRating {
+	r := partial.records[teamName].total()
+
+	wp := partial.wp(teamName, "")
+	owp := partial.weightedAverage(teamName, func(opponentName string) *big.Rat {
+		return t.opponentWP(opponentName, teamName)
+	})
+	oowp := partial.weightedAverage(teamName, func(opponentName string) *big.Rat {
+		return owps[opponentName]
+	})
+
+	return &ExactRating{
+		Team:   teamName,
+		Wins:   r.Wins,
+		Losses: r.Losses,
+		Ties:   r.Ties,
+		WP:     NewElement(wp),
+		OWP:    NewElement(owp),
+		OOWP:   NewElement(oowp),
+		RPI:    NewElement(t.combine(wp, owp, oowp)),
+	}
+}
+
+// SplitRating is a team's rating over its whole schedule side by side with its ratings over its
+// conference and non-conference matches, which are nil when it played none.
+type SplitRating struct {
+	Team       string
+	Conference string
+
+	Overall       *Rating
+	InConference  *Rating
+	NonConference *Rating
+}
+
+// SplitRatings is the split ratings of every team in a schedule.
+type SplitRatings []*SplitRating
+
+// Find returns the split rating for the specified team or nil if the team has no rating.
+func (r SplitRatings) Find(teamName string) *SplitRating {
+	for _, rating := range r {
+		if rating.Team == teamName {
+			return rating
+		}
+	}
+
+	return nil
+}
+
+// SortByRPI orders the split ratings from the highest overall RPI to the lowest.
+func (r SplitRatings) SortByRPI() {
+	sortByValue(r, func(rating *SplitRating) (string, float64) {
+		return rating.Team, rating.Overall.RPI
+	})
+}
+
+// CalculateSplits calculates every eligible team's rating over all of its matches, its conference
+// matches and its non-conference matches from a single snapshot of the schedule.
+func (s *Schedule) CalculateSplits() (SplitRatings, error) {
+	v := s.snapshot()
+	if v.registry == nil {
+		return nil, fmt.Errorf("conference splits require a registry")
+	}
+
+	return v.splits(), nil
+}
+
+func (v *snapshot) splits() SplitRatings {
+	inConference := v.view(ViewConference)
+	nonConference := v.view(ViewNonConference)
+
+	overall := v.view(ViewAll)
+	splits := make(SplitRatings, 0, len(overall))
+	for _, rating := range overall {
+		splits = append(splits, &SplitRating{
+			Team:          rating.Team,
+			Conference:    v.registry.Conference(rating.Team),
+			Overall:       rating,
+			InConference:  inConference.Find(rating.Team),
+			NonConference: nonConference.Find(rating.Team),
+		})
+	}
+
+	return splits
+}
+
+// ConferenceRating aggregates the ratings of a conference's members.
+type ConferenceRating struct {
+	Conference string
+
+	// Teams is the number of rated members.
+	Teams int
+
+	// RPI is the average RPI of the members and NonConferenceRPI is the average RPI of the members over
+	// their non-conference matches.  Members whose RPI is undefined are left out of the averages.
+	RPI              float64
+	NonConferenceRPI float64
+
+	// NonConference is the combined record of the members in non-conference matches.
+	NonConference Record
+}
+
+// ConferenceRatings is the aggregate rating of every conference.
+type ConferenceRatings []*ConferenceRating
+
+// SortByRPI orders the conferences from the highest average RPI to the lowest.
+func (r ConferenceRatings) SortByRPI() {
+	sortByValue(r, func(rating *ConferenceRating) (string, float64) {
+		return rating.Conference, rating.RPI
+	})
+}
+
+// CalculateConferences aggregates the ratings of the members of every conference.  Teams without a
+// conference aren't included.
+func (s *Schedule) CalculateConferences() (ConferenceRatings, error) {
+	v := s.snapshot()
+	if v.registry == nil {
+		return nil, fmt.Errorf("conference ratings require a registry")
+	}
+
+	type sums struct {
+		rating               *ConferenceRating
+		rpi, nonConference   float64
+		rpis, nonConferences int
+	}
+
+	var order []string
+	byConference := make(map[string]*sums)
+	for _, split := range v.splits() {
+		if split.Conference == "" {
+			continue
+		}
+
+		c, ok := byConference[split.Conference]
+		if !ok {
+			c = &sums{rating: &ConferenceRating{Conference: split.Conference}}
+			byConference[split.Conference] = c
+			order = append(order, split.Conference)
+		}
+
+		c.rating.Teams++
+		if !math.IsNaN(split.Overall.RPI) {
+			c.rpi += split.Overall.RPI
+			c.rpis++
+		}
+
+		if nc := split.NonConference; nc != nil {
+			c.rating.NonConference = c.rating.NonConference.plus(Record{Wins: nc.Wins, Losses: nc.Losses, Ties: nc.Ties})
+			if !math.IsNaN(nc.RPI) {
+				c.nonConference += nc.RPI
+				c.nonConferences++
+			}
+		}
+	}
+
+	ratings := make(ConferenceRatings, 0, len(order))
+	for _, conference := range order {
+		c := byConference[conference]
+		c.rating.RPI = average(c.rpi, c.rpis)
+		c.rating.NonConferenceRPI = average(c.nonConference, c.nonConferences)
+		ratings = append(ratings, c.rating)
+	}
+
+	ratings.SortByRPI()
+
+	return ratings, nil
+}
+
+// average returns the sum divided by the count, or NaN when the count is zero.
+func average(sum float64, count int) float64 {
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return sum / float64(count)
+}
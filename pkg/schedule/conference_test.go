@@ -0,0 +1,189 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conferences", func() {
+	var pSchedule *schedule.Schedule
+	var registry *team.Registry
+
+	register := func(name, conference string) {
+		t := team.NewTeam(name)
+		t.Division = team.DivisionI
+		t.Conference = conference
+		Expect(registry.Register(t)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		// East is A and B and West is C and D.  A and B and C and D meet in conference and the other
+		// three matches are non-conference.
+		pSchedule.AddMatchFromString("A,2,B,1")
+		pSchedule.AddMatchFromString("C,2,D,1")
+		pSchedule.AddMatchFromString("A,2,C,1")
+		pSchedule.AddMatchFromString("D,2,B,1")
+		pSchedule.AddMatchFromString("B,2,C,1")
+
+		registry = team.NewRegistry()
+		register("A", "East")
+		register("B", "East")
+		register("C", "West")
+		register("D", "West")
+		pSchedule.SetRegistry(registry)
+	})
+
+	Describe("CalculateView", func() {
+		It("should rate every match in the all view", func() {
+			// Arrange
+			expected, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings, err := pSchedule.CalculateView(schedule.ViewAll)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(Equal(expected))
+		})
+
+		It("should rate conference matches against opponents' full records", func() {
+			// Act
+			ratings, err := pSchedule.CalculateView(schedule.ViewConference)
+
+			// Assert
+			// A's only conference opponent is B, who is 1-1 without A, and B's OWP over its full schedule
+			// is (1 + 0 + 0.5) / 3.
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(4))
+
+			a := ratings.Find("A")
+			Expect(a.Wins).To(Equal(1))
+			Expect(a.Losses).To(Equal(0))
+			Expect(a.WP).To(Equal(1.0))
+			Expect(a.OWP).To(Equal(0.5))
+			Expect(a.OOWP).To(Equal(0.5))
+			Expect(a.RPI).To(Equal(0.625))
+		})
+
+		It("should rate non-conference matches against opponents' full records", func() {
+			// Act
+			ratings, err := pSchedule.CalculateView(schedule.ViewNonConference)
+
+			// Assert
+			// A's only non-conference opponent is C, who is 1-1 without A, and C's OWP over its full
+			// schedule is (1 + 1 + 0) / 3.
+			Expect(err).NotTo(HaveOccurred())
+
+			a := ratings.Find("A")
+			Expect(a.WP).To(Equal(1.0))
+			Expect(a.OWP).To(Equal(0.5))
+			Expect(a.OOWP).To(BeNumerically("~", 2.0/3, 1e-12))
+			Expect(a.RPI).To(BeNumerically("~", 0.25+0.25+0.25*2/3, 1e-12))
+
+			b := ratings.Find("B")
+			Expect(b.Wins).To(Equal(1))
+			Expect(b.Losses).To(Equal(1))
+		})
+
+		It("should treat matches against a team without a conference as non-conference", func() {
+			// Arrange
+			register("E", "")
+			pSchedule.AddMatchFromString("E,2,A,1")
+
+			// Act
+			conference, err := pSchedule.CalculateView(schedule.ViewConference)
+			Expect(err).NotTo(HaveOccurred())
+			nonConference, err := pSchedule.CalculateView(schedule.ViewNonConference)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			Expect(conference.Find("E")).To(BeNil())
+			Expect(nonConference.Find("E").Wins).To(Equal(1))
+			Expect(nonConference.Find("A").Losses).To(Equal(1))
+		})
+
+		It("should require a registry for the conference views", func() {
+			// Arrange
+			pSchedule.SetRegistry(nil)
+
+			// Act
+			_, err := pSchedule.CalculateView(schedule.ViewNonConference)
+
+			// Assert
+			Expect(err).To(MatchError("the non-conference view requires a registry"))
+		})
+	})
+
+	Describe("CalculateSplits", func() {
+		It("should put every view of a team side by side", func() {
+			// Arrange
+			all, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			splits, err := pSchedule.CalculateSplits()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(splits).To(HaveLen(4))
+
+			splits.SortByRPI()
+			Expect(splits[0].Team).To(Equal("A"))
+
+			a := splits.Find("A")
+			Expect(a.Conference).To(Equal("East"))
+			Expect(a.Overall).To(Equal(all.Find("A")))
+			Expect(a.InConference.RPI).To(Equal(0.625))
+			Expect(a.NonConference.Wins).To(Equal(1))
+		})
+	})
+
+	Describe("CalculateConferences", func() {
+		It("should aggregate the ratings of each conference's members", func() {
+			// Arrange
+			all, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+			nonConference, err := pSchedule.CalculateView(schedule.ViewNonConference)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings, err := pSchedule.CalculateConferences()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(2))
+
+			east := ratings[0]
+			Expect(east.Conference).To(Equal("East"))
+			Expect(east.Teams).To(Equal(2))
+			Expect(east.RPI).To(BeNumerically("~", (all.Find("A").RPI+all.Find("B").RPI)/2, 1e-12))
+			Expect(east.NonConferenceRPI).To(BeNumerically("~", (nonConference.Find("A").RPI+nonConference.Find("B").RPI)/2, 1e-12))
+			Expect(east.NonConference).To(Equal(schedule.Record{Wins: 2, Losses: 1}))
+
+			west := ratings[1]
+			Expect(west.Conference).To(Equal("West"))
+			Expect(west.NonConference).To(Equal(schedule.Record{Wins: 1, Losses: 2}))
+			Expect(math.IsNaN(west.RPI)).To(BeFalse())
+		})
+
+		It("should leave out teams without a conference", func() {
+			// Arrange
+			register("E", "")
+			pSchedule.AddMatchFromString("E,2,A,1")
+
+			// Act
+			ratings, err := pSchedule.CalculateConferences()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(2))
+		})
+	})
+})
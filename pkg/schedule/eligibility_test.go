@@ -0,0 +1,136 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Eligibility", func() {
+	var pSchedule *schedule.Schedule
+	var registry *team.Registry
+
+	register := func(name string, division team.Division) {
+		t := team.NewTeam(name)
+		t.Division = division
+		Expect(registry.Register(t)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("UConn,64,Kansas,57")
+		pSchedule.AddMatchFromString("UConn,82,Duke,68")
+		pSchedule.AddMatchFromString("Wisconsin,71,UConn,72")
+		pSchedule.AddMatchFromString("Kansas,69,UConn,62")
+		pSchedule.AddMatchFromString("Duke,81,Wisconsin,70")
+		pSchedule.AddMatchFromString("Wisconsin,52,Kansas,62")
+		pSchedule.AddMatchFromString("Kansas,60,Duke,66")
+
+		registry = team.NewRegistry()
+		register("UConn", team.DivisionI)
+		register("Kansas", team.DivisionI)
+		register("Duke", team.DivisionI)
+		register("Wisconsin", team.DivisionII)
+	})
+
+	It("should rate every match without a registry", func() {
+		Expect(pSchedule.GetRatedMatches()).To(HaveLen(7))
+		Expect(pSchedule.IsEligible("Wisconsin")).To(BeTrue())
+	})
+
+	It("should only rate matches between eligible teams", func() {
+		// Arrange
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		matches := pSchedule.GetRatedMatches()
+
+		// Assert
+		Expect(matches).To(HaveLen(4))
+		for _, m := range matches {
+			Expect(m.Contains("Wisconsin")).To(BeFalse())
+		}
+	})
+
+	It("should keep the full record", func() {
+		// Arrange
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		record, err := pSchedule.GetRecord("UConn")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(record.ToString()).To(Equal("3-1-0"))
+	})
+
+	It("should calculate the RPI from the eligible matches only", func() {
+		// Arrange
+		filtered := schedule.NewSchedule()
+		filtered.AddMatchFromString("UConn,64,Kansas,57")
+		filtered.AddMatchFromString("UConn,82,Duke,68")
+		filtered.AddMatchFromString("Kansas,69,UConn,62")
+		filtered.AddMatchFromString("Kansas,60,Duke,66")
+
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		wp, err := pSchedule.CalculateWP("UConn", "")
+		Expect(err).NotTo(HaveOccurred())
+		rpi, err := pSchedule.CalculateRPI("UConn")
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := filtered.CalculateRPI("UConn")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Assert
+		Expect(wp).To(BeNumerically("~", 2.0/3.0, 1e-12))
+		Expect(math.IsNaN(rpi)).To(BeFalse())
+		Expect(rpi).To(Equal(expected))
+	})
+
+	It("should leave ineligible teams out of the ratings", func() {
+		// Arrange
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		ratings, err := pSchedule.CalculateAll()
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ratings).To(HaveLen(3))
+		Expect(ratings.Find("Wisconsin")).To(BeNil())
+		Expect(ratings.Find("UConn").Wins).To(Equal(2))
+	})
+
+	It("should return an error when rating an ineligible team", func() {
+		// Arrange
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		_, err := pSchedule.CalculateRPI("Wisconsin")
+
+		// Assert
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("team Wisconsin is not eligible for the RPI"))
+	})
+
+	It("should treat unregistered teams as ineligible", func() {
+		// Arrange
+		pSchedule.AddMatchFromString("UConn,3,Nowhere State,0")
+		pSchedule.SetRegistry(registry)
+
+		// Act
+		record, err := pSchedule.GetRecord("UConn")
+		Expect(err).NotTo(HaveOccurred())
+		ratings, err := pSchedule.CalculateAll()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Assert
+		Expect(record.Wins).To(Equal(4))
+		Expect(ratings.Find("UConn").Wins).To(Equal(2))
+	})
+})
@@ -0,0 +1,178 @@
+package schedule
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Element is the exact value of an element of the RPI.  An element is undefined when it would
+// divide by zero, such as the winning percentage of a team without any rated matches.
+type Element struct {
+	rat *big.Rat
+}
+
+// NewElement returns an element with the value of r, or an undefined element when r is nil.
+func NewElement(r *big.Rat) Element {
+	if r == nil {
+		return Element{}
+	}
+
+	return Element{rat: new(big.Rat).Set(r)}
+}
+
+// IsDefined reports whether the element has a value.
+func (e Element) IsDefined() bool {
+	return e.rat != nil
+}
+
+// Rat returns a copy of the exact value, or nil when the element is undefined.
+func (e Element) Rat() *big.Rat {
+	if e.rat == nil {
+		return nil
+	}
+
+	return new(big.Rat).Set(e.rat)
+}
+
+// Float returns the value as the nearest float64, or NaN when the element is undefined.
+func (e Element) Float() float64 {
+	if e.rat == nil {
+		return math.NaN()
+	}
+
+	f, _ := e.rat.Float64()
+	return f
+}
+
+// Fraction returns the value as a reduced fraction such as "3/4", or "NaN" when the element is undefined.
+func (e Element) Fraction() string {
+	if e.rat == nil {
+		return "NaN"
+	}
+
+	return e.rat.RatString()
+}
+
+// Decimal returns the value rounded to the specified number of decimal places, or "NaN" when the
+// element is undefined.  Halves are rounded away from zero.
+func (e Element) Decimal(places int) string {
+	if e.rat == nil {
+		return "NaN"
+	}
+
+	return e.rat.FloatString(places)
+}
+
+// Cmp compares two elements exactly, returning -1, 0 or +1.  An undefined element is less than any
+// defined element.
+func (e Element) Cmp(other Element) int {
+	switch {
+	case e.rat == nil && other.rat == nil:
+		return 0
+	case e.rat == nil:
+		return -1
+	case other.rat == nil:
+		return 1
+	}
+
+	return e.rat.Cmp(other.rat)
+}
+
+// ExactRating holds the exact RPI elements computed for a single team.
+type ExactRating struct {
+	Team   string
+	Wins   int
+	Losses int
+	Ties   int
+	WP     Element
+	OWP    Element
+	OOWP   Element
+	RPI    Element
+}
+
+// Rating returns the rating with each element converted to the nearest float64.
+func (r *ExactRating) Rating() *Rating {
+	return &Rating{
+		Team:   r.Team,
+		Wins:   r.Wins,
+		Losses: r.Losses,
+		Ties:   r.Ties,
+		WP:     r.WP.Float(),
+		OWP:    r.OWP.Float(),
+		OOWP:   r.OOWP.Float(),
+		RPI:    r.RPI.Float(),
+	}
+}
+
+// ExactRatings is the collection of exact ratings for every team in a schedule.
+type ExactRatings []*ExactRating
+
+// Find returns the exact rating for the specified team or nil if the team has no rating.
+func (r ExactRatings) Find(teamName string) *ExactRating {
+	for _, rating := range r {
+		if rating.Team == teamName {
+			return rating
+		}
+	}
+
+	return nil
+}
+
+// SortByRPI orders the ratings from the highest RPI to the lowest comparing the exact values, so
+// ratings that differ only beyond float64 precision are still ordered correctly.  Equal ratings are
+// ordered by team name and ratings with an undefined RPI are placed last.
+func (r ExactRatings) SortByRPI() {
+	sort.SliceStable(r, func(i, j int) bool {
+		if c := r[i].RPI.Cmp(r[j].RPI); c != 0 {
+			return c > 0
+		}
+
+		return r[i].Team < r[j].Team
+	})
+}
+
+// exactFormula holds the formula's weights and values as the rational numbers they were written as.
+type exactFormula struct {
+	// elements holds the WP, OWP and OOWP weights.
+	elements [3]*big.Rat
+
+	// values holds the credit for each result, indexed by Result.
+	values [ResultTie + 1]*big.Rat
+
+	// weighting holds the number of games each result counts as, indexed by Location and then Result.
+	weighting [LocationNeutral + 1][ResultTie + 1]*big.Rat
+}
+
+func newExactFormula(f Formula) exactFormula {
+	var e exactFormula
+
+	e.elements = [3]*big.Rat{exact(f.WPWeight), exact(f.OWPWeight), exact(f.OOWPWeight)}
+
+	e.values[ResultWin] = exact(f.WinValue)
+	e.values[ResultLoss] = exact(f.LossValue)
+	e.values[ResultTie] = exact(f.TieValue)
+
+	for i := range e.weighting {
+		location := Location(i)
+		e.weighting[i][ResultWin] = exact(f.Weighting.Win(location))
+		e.weighting[i][ResultLoss] = exact(f.Weighting.Loss(location))
+		e.weighting[i][ResultTie] = exact(f.Weighting.Tie(location))
+	}
+
+	return e
+}
+
+// exact converts a configured value to the rational number it was written as, so a weight of 0.21
+// is exactly 21/100 rather than the nearest binary fraction.
+func exact(f float64) *big.Rat {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if !ok {
+		return new(big.Rat).SetFloat64(f)
+	}
+
+	return r
+}
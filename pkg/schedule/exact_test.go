@@ -0,0 +1,156 @@
+package schedule_test
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exact", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("Team A,1,Team B,0")
+		pSchedule.AddMatchFromString("Team A,1,Team B,1")
+		pSchedule.AddMatchFromString("Team A,2,Team C,2")
+		pSchedule.AddMatchFromString("Team C,0,Team A,0")
+		pSchedule.AddMatchFromString("Team B,3,Team C,1")
+	})
+
+	Describe("Element", func() {
+		It("should provide the fraction and the rounded decimal", func() {
+			// Arrange
+			element := schedule.NewElement(big.NewRat(2, 3))
+
+			// Assert
+			Expect(element.IsDefined()).To(BeTrue())
+			Expect(element.Fraction()).To(Equal("2/3"))
+			Expect(element.Decimal(4)).To(Equal("0.6667"))
+			Expect(element.Float()).To(Equal(2.0 / 3.0))
+		})
+
+		It("should be undefined without a value", func() {
+			// Arrange
+			element := schedule.NewElement(nil)
+
+			// Assert
+			Expect(element.IsDefined()).To(BeFalse())
+			Expect(element.Rat()).To(BeNil())
+			Expect(math.IsNaN(element.Float())).To(BeTrue())
+			Expect(element.Fraction()).To(Equal("NaN"))
+			Expect(element.Decimal(4)).To(Equal("NaN"))
+		})
+
+		It("should not share its value", func() {
+			// Arrange
+			r := big.NewRat(1, 2)
+			element := schedule.NewElement(r)
+
+			// Act
+			r.SetInt64(1)
+			element.Rat().SetInt64(1)
+
+			// Assert
+			Expect(element.Fraction()).To(Equal("1/2"))
+		})
+	})
+
+	Describe("CalculateExact", func() {
+		It("should count every tie as half a win", func() {
+			// Act
+			rating, err := pSchedule.CalculateExact("Team A")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rating.Wins).To(Equal(1))
+			Expect(rating.Ties).To(Equal(3))
+			Expect(rating.WP.Fraction()).To(Equal("5/8"))
+			Expect(rating.WP.Decimal(4)).To(Equal("0.6250"))
+		})
+
+		It("should weight the elements exactly", func() {
+			// Arrange
+			Expect(pSchedule.SetFormula(schedule.IceHockeyFormula)).To(Succeed())
+
+			// Act
+			rating, err := pSchedule.CalculateExact("Team A")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			expected := new(big.Rat).Mul(rating.WP.Rat(), big.NewRat(25, 100))
+			expected.Add(expected, new(big.Rat).Mul(rating.OWP.Rat(), big.NewRat(21, 100)))
+			expected.Add(expected, new(big.Rat).Mul(rating.OOWP.Rat(), big.NewRat(54, 100)))
+			Expect(rating.RPI.Rat().Cmp(expected)).To(Equal(0))
+		})
+
+		It("should agree with the per-team methods", func() {
+			for _, teamName := range pSchedule.GetTeams() {
+				// Act
+				rating, err := pSchedule.CalculateExact(teamName)
+				Expect(err).NotTo(HaveOccurred())
+				rpi, err := pSchedule.CalculateRPI(teamName)
+				Expect(err).NotTo(HaveOccurred())
+				owp, err := pSchedule.CalculateOWP(teamName)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Assert
+				Expect(rating.RPI.Float()).To(Equal(rpi))
+				Expect(rating.OWP.Float()).To(Equal(owp))
+			}
+		})
+
+		It("should return an error for a team that doesn't exist", func() {
+			// Act
+			_, err := pSchedule.CalculateExact("Team Z")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no matches found for team Team Z"))
+		})
+	})
+
+	Describe("CalculateAllExact", func() {
+		It("should agree with CalculateAll", func() {
+			// Act
+			exactRatings, err := pSchedule.CalculateAllExact()
+			Expect(err).NotTo(HaveOccurred())
+			ratings, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			Expect(exactRatings).To(HaveLen(len(ratings)))
+			for _, rating := range ratings {
+				Expect(exactRatings.Find(rating.Team).Rating()).To(Equal(rating))
+			}
+		})
+	})
+
+	Describe("SortByRPI", func() {
+		It("should order ratings that are equal as floats by their exact values", func() {
+			// Arrange
+			third := big.NewRat(1, 3)
+			justOverAThird := new(big.Rat).Add(third, new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)))
+			ratings := schedule.ExactRatings{
+				{Team: "Team A", RPI: schedule.NewElement(third)},
+				{Team: "Team B", RPI: schedule.NewElement(nil)},
+				{Team: "Team C", RPI: schedule.NewElement(justOverAThird)},
+				{Team: "Team D", RPI: schedule.NewElement(third)},
+			}
+			Expect(ratings[0].RPI.Float()).To(Equal(ratings[2].RPI.Float()))
+
+			// Act
+			ratings.SortByRPI()
+
+			// Assert
+			Expect(ratings[0].Team).To(Equal("Team C"))
+			Expect(ratings[1].Team).To(Equal("Team A"))
+			Expect(ratings[2].Team).To(Equal("Team D"))
+			Expect(ratings[3].Team).To(Equal("Team B"))
+		})
+	})
+})
@@ -0,0 +1,131 @@
+package schedule
+
+import (
+	"math/big"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Contribution is what a single match adds to a team's rating.
+type Contribution struct {
+	Match    *Match
+	Opponent string
+	Result   Result
+
+	// WP, OWP and OOWP are the match's shares of each element, so that the shares of every match add up
+	// to the element.  A share is NaN when the opponent's winning percentage it depends on is undefined.
+	WP   float64
+	OWP  float64
+	OOWP float64
+
+	// RPI is the match's share of the RPI, its shares of the elements combined by the formula.
+	RPI float64
+
+	// RPIWithout is the team's RPI with the match removed from the schedule, which also removes it from
+	// its opponents' records, or NaN when the RPI would be undefined.
+	RPIWithout float64
+
+	// Change is how the team's RPI would move if the match were removed.  A match that helps the team
+	// has a negative change.
+	Change float64
+}
+
+// Contributions is the contribution of every match a team has played.
+type Contributions []*Contribution
+
+// SortBestFirst orders the matches from the one that helps the team's RPI most to the one that hurts
+// it most.  Matches whose removal would leave the RPI undefined are placed last.
+func (c Contributions) SortBestFirst() {
+	sortByValue(c, func(contribution *Contribution) (string, float64) {
+		return contribution.Match.ID, -contribution.Change
+	})
+}
+
+// SortWorstFirst orders the matches from the one that hurts the team's RPI most to the one that helps
+// it most.  Matches whose removal would leave the RPI undefined are placed last.
+func (c Contributions) SortWorstFirst() {
+	sortByValue(c, func(contribution *Contribution) (string, float64) {
+		return contribution.Match.ID, contribution.Change
+	})
+}
+
+// SortByDate orders the matches from the earliest to the latest.
+func (c Contributions) SortByDate() {
+	slices.SortStableFunc(c, func(a, b *Contribution) int {
+		return a.Match.Date.Compare(b.Match.Date)
+	})
+}
+
+// Explanation breaks a team's rating down into the contribution of each match it has played.
+type Explanation struct {
+	*Rating
+
+	// Contributions lists every rated match the team has played in the order of the schedule.  Matches
+	// the formula's decision rules exclude aren't listed.
+	Contributions Contributions
+}
+
+// Explain returns the team's rating with the share of each element that each of its rated matches
+// contributes and how its RPI would change if that match alone were removed.  Each opponent's
+// winning percentages are averaged over the team's matches, so a match contributes its opponent's
+// winning percentage and its opponent's OWP divided by the number of matches the team has played.
+func (s *Schedule) Explain(teamName string) (*Explanation, error) {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
+		return nil, err
+	}
+
+	matches := v.ratedMatches()
+	t := newTally(matches, v.formula)
+	owps := t.owps(append([]string{teamName}, t.opponents[teamName]...))
+
+	explanation := &Explanation{Rating: t.rating(teamName, owps).Rating()}
+
+	_, games := t.weightedCredit(t.records[teamName], t.formula.WeightedWP)
+	count := new(big.Rat).SetInt64(int64(t.records[teamName].total().Total()))
+
+	for i, m := range matches {
+		result := m.ResultUnder(teamName, v.formula.Decisions)
+		if result == ResultNone {
+			continue
+		}
+
+		opponentName, err := m.GetOpponent(teamName)
+		if err != nil {
+			return nil, err
+		}
+
+		credit, _ := t.weightedCredit(t.result(teamName, m), t.formula.WeightedWP)
+		wp := share(credit, games)
+		owp := share(t.opponentWP(opponentName, teamName), count)
+		oowp := share(owps[opponentName], count)
+
+		without := newTally(slices.Delete(slices.Clone(matches), i, i+1), v.formula).rpi(teamName)
+
+		contribution := &Contribution{
+			Match:      m,
+			Opponent:   opponentName,
+			Result:     result,
+			WP:         NewElement(wp).Float(),
+			OWP:        NewElement(owp).Float(),
+			OOWP:       NewElement(oowp).Float(),
+			RPI:        NewElement(t.combine(wp, owp, oowp)).Float(),
+			RPIWithout: NewElement(without).Float(),
+		}
+		contribution.Change = contribution.RPIWithout - explanation.RPI
+
+		explanation.Contributions = append(explanation.Contributions, contribution)
+	}
+
+	return explanation, nil
+}
+
+// share returns value divided by total, or nil when either is undefined or the total is zero.
+func share(value, total *big.Rat) *big.Rat {
+	if value == nil || total == nil || total.Sign() == 0 {
+		return nil
+	}
+
+	return new(big.Rat).Quo(value, total)
+}
@@ -0,0 +1,159 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var eSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		eSchedule = schedule.NewSchedule()
+
+		for _, line := range []string{
+			"2023-09-01,A,2,B,0",
+			"2023-09-02,C,1,D,0",
+			"2023-09-03,A,1,C,0,N",
+			"2023-09-04,B,1,D,1",
+			"2023-09-05,D,2,E,0",
+			"2023-09-06,E,1,B,0",
+			"2023-09-07,C,2,E,2",
+			"2023-09-08,D,2,A,1",
+			"2023-09-10,E,,A,",
+		} {
+			Expect(eSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+	})
+
+	sum := func(contributions schedule.Contributions, value func(*schedule.Contribution) float64) float64 {
+		total := 0.0
+		for _, contribution := range contributions {
+			total += value(contribution)
+		}
+
+		return total
+	}
+
+	It("should list every rated match with shares that add up to the elements", func() {
+		// Arrange
+		rating, err := eSchedule.CalculateAll()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Act
+		explanation, err := eSchedule.Explain("A")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*explanation.Rating).To(Equal(*rating.Find("A")))
+		Expect(explanation.Contributions).To(HaveLen(3))
+
+		first := explanation.Contributions[0]
+		Expect(first.Match.ID).To(Equal("2023-09-01-a-b"))
+		Expect(first.Opponent).To(Equal("B"))
+		Expect(first.Result).To(Equal(match.ResultWin))
+		Expect(first.WP).To(BeNumerically("~", 1.0/3, 1e-12))
+
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.WP })).To(BeNumerically("~", explanation.WP, 1e-12))
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.OWP })).To(BeNumerically("~", explanation.OWP, 1e-12))
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.OOWP })).To(BeNumerically("~", explanation.OOWP, 1e-12))
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.RPI })).To(BeNumerically("~", explanation.RPI, 1e-12))
+	})
+
+	It("should give the RPI the team would have without each match", func() {
+		// Act
+		explanation, err := eSchedule.Explain("A")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, contribution := range explanation.Contributions {
+			without := schedule.NewSchedule()
+			for _, m := range eSchedule.GetMatches() {
+				if m.ID != contribution.Match.ID {
+					Expect(without.AddMatch(m.Clone())).To(Succeed())
+				}
+			}
+
+			rpi, err := without.CalculateRPI("A")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contribution.RPIWithout).To(Equal(rpi))
+			Expect(contribution.Change).To(Equal(rpi - explanation.RPI))
+		}
+	})
+
+	It("should sort the matches from the best to the worst and back", func() {
+		// Arrange
+		explanation, err := eSchedule.Explain("A")
+		Expect(err).NotTo(HaveOccurred())
+		contributions := explanation.Contributions
+
+		// Act
+		contributions.SortBestFirst()
+
+		// Assert
+		for i := 1; i < len(contributions); i++ {
+			Expect(contributions[i].Change).To(BeNumerically(">=", contributions[i-1].Change))
+		}
+		Expect(contributions[len(contributions)-1].Match.ID).To(Equal("2023-09-08-d-a"))
+
+		// Act
+		contributions.SortWorstFirst()
+
+		// Assert
+		Expect(contributions[0].Match.ID).To(Equal("2023-09-08-d-a"))
+		Expect(contributions[0].Change).To(BeNumerically(">", 0))
+
+		// Act
+		contributions.SortByDate()
+
+		// Assert
+		Expect(contributions[0].Match.ID).To(Equal("2023-09-01-a-b"))
+		Expect(contributions[2].Match.ID).To(Equal("2023-09-08-d-a"))
+	})
+
+	It("should weight the shares of the WP by location when the formula does", func() {
+		// Arrange
+		Expect(eSchedule.SetFormula(schedule.BasketballFormula)).To(Succeed())
+
+		// Act
+		explanation, err := eSchedule.Explain("A")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.WP })).To(BeNumerically("~", explanation.WP, 1e-12))
+		Expect(sum(explanation.Contributions, func(c *schedule.Contribution) float64 { return c.RPI })).To(BeNumerically("~", explanation.RPI, 1e-12))
+		Expect(explanation.Contributions[0].WP).NotTo(BeNumerically("~", explanation.Contributions[1].WP, 1e-12))
+	})
+
+	It("should leave the RPI undefined when the team's only match is removed", func() {
+		// Arrange
+		Expect(eSchedule.AddMatchFromString("2023-09-12,F,1,B,0")).To(Succeed())
+
+		// Act
+		explanation, err := eSchedule.Explain("F")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(explanation.Contributions).To(HaveLen(1))
+		Expect(math.IsNaN(explanation.Contributions[0].RPIWithout)).To(BeTrue())
+		Expect(math.IsNaN(explanation.Contributions[0].Change)).To(BeTrue())
+	})
+
+	DescribeTable("should reject a team it can't explain",
+		func(teamName, message string) {
+			// Act
+			explanation, err := eSchedule.Explain(teamName)
+
+			// Assert
+			Expect(explanation).To(BeNil())
+			Expect(err).To(MatchError(message))
+		},
+		Entry("an empty name", "", "the specified team name is empty"),
+		Entry("an unknown team", "Z", "no matches found for team Z"),
+	)
+})
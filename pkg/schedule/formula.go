@@ -0,0 +1,289 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Formula defines how the elements of the RPI are calculated and combined.
+type Formula struct {
+	Name string
+
+	// WPWeight, OWPWeight and OOWPWeight are the weights of the three elements in the RPI.
+	WPWeight   float64
+	OWPWeight  float64
+	OOWPWeight float64
+
+	// WinValue, TieValue and LossValue are the credit a team receives for each result when
+	// calculating a winning percentage.
+	WinValue  float64
+	TieValue  float64
+	LossValue float64
+
+	// OWPExcludesTeam removes each opponent's games against the team being rated when
+	// calculating the opponents' winning percentage.
+	OWPExcludesTeam bool
+
+	// WeightedWP weights the team's own results by location when calculating its winning
+	// percentage.  The opponents' winning percentages are never weighted.
+	WeightedWP bool
+	Weighting  Weighting
+
+	// Decisions is how matches are credited according to how they were decided, such as whether a
+	// shootout counts as a tie or a win.
+	Decisions DecisionRules
+}
+
+// Weighting is the number of games a result counts as, by location, when calculating a
+// weighted winning percentage.
+type Weighting struct {
+	HomeWin     float64
+	AwayWin     float64
+	NeutralWin  float64
+	HomeLoss    float64
+	AwayLoss    float64
+	NeutralLoss float64
+	HomeTie     float64
+	AwayTie     float64
+	NeutralTie  float64
+}
+
+var (
+	// BasketballWeighting is the weighting used for Division I basketball: a home win counts as
+	// 0.6 of a win, a road win as 1.4, a home loss as 1.4 of a loss and a road loss as 0.6.
+	BasketballWeighting = Weighting{
+		HomeWin:     0.6,
+		AwayWin:     1.4,
+		NeutralWin:  1.0,
+		HomeLoss:    1.4,
+		AwayLoss:    0.6,
+		NeutralLoss: 1.0,
+		HomeTie:     1.0,
+		AwayTie:     1.0,
+		NeutralTie:  1.0,
+	}
+
+	// BaseballWeighting is the weighting used for Division I baseball: a home win counts as 0.7
+	// of a win, a road win as 1.3, a home loss as 1.3 of a loss and a road loss as 0.7.
+	BaseballWeighting = Weighting{
+		HomeWin:     0.7,
+		AwayWin:     1.3,
+		NeutralWin:  1.0,
+		HomeLoss:    1.3,
+		AwayLoss:    0.7,
+		NeutralLoss: 1.0,
+		HomeTie:     1.0,
+		AwayTie:     1.0,
+		NeutralTie:  1.0,
+	}
+)
+
+// Win returns the weight of a win at the specified location.
+func (w Weighting) Win(location Location) float64 {
+	return w.pick(location, w.HomeWin, w.AwayWin, w.NeutralWin)
+}
+
+// Loss returns the weight of a loss at the specified location.
+func (w Weighting) Loss(location Location) float64 {
+	return w.pick(location, w.HomeLoss, w.AwayLoss, w.NeutralLoss)
+}
+
+// Tie returns the weight of a tie at the specified location.
+func (w Weighting) Tie(location Location) float64 {
+	return w.pick(location, w.HomeTie, w.AwayTie, w.NeutralTie)
+}
+
+func (w Weighting) pick(location Location, home, away, neutral float64) float64 {
+	switch location {
+	case LocationHome:
+		return home
+	case LocationAway:
+		return away
+	}
+
+	return neutral
+}
+
+func (w Weighting) validate() bool {
+	for _, weight := range []float64{
+		w.HomeWin, w.AwayWin, w.NeutralWin,
+		w.HomeLoss, w.AwayLoss, w.NeutralLoss,
+		w.HomeTie, w.AwayTie, w.NeutralTie,
+	} {
+		if weight < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+var (
+	// SoccerFormula is the formula used for Division I men's and women's soccer, where games
+	// determined by penalty kicks are considered ties.
+	SoccerFormula = Formula{
+		Name:            "soccer",
+		WPWeight:        0.25,
+		OWPWeight:       0.50,
+		OOWPWeight:      0.25,
+		WinValue:        1.0,
+		TieValue:        0.5,
+		LossValue:       0.0,
+		OWPExcludesTeam: true,
+		Decisions:       DecisionRules{DecisionShootout: CreditTie},
+	}
+
+	// BaseballFormula is the formula used for Division I baseball.
+	BaseballFormula = SoccerFormula.named("baseball").weighted(BaseballWeighting)
+
+	// SoftballFormula is the formula used for Division I softball.
+	SoftballFormula = SoccerFormula.named("softball")
+
+	// BasketballFormula is the formula used for Division I basketball before it was replaced by the NET.
+	BasketballFormula = SoccerFormula.named("basketball").weighted(BasketballWeighting)
+
+	// VolleyballFormula is the formula used for Division I men's and women's volleyball.
+	VolleyballFormula = SoccerFormula.named("volleyball")
+
+	// FieldHockeyFormula is the formula used for Division I field hockey.
+	FieldHockeyFormula = SoccerFormula.named("field-hockey")
+
+	// LacrosseFormula is the formula used for Division I men's and women's lacrosse.
+	LacrosseFormula = SoccerFormula.named("lacrosse")
+
+	// WaterPoloFormula is the formula used for Division I women's water polo.
+	WaterPoloFormula = SoccerFormula.named("water-polo")
+
+	// IceHockeyFormula is the formula used for Division I men's and women's ice hockey, which weights
+	// the opponents' opponents more heavily than the opponents and treats shootouts as ties.
+	IceHockeyFormula = Formula{
+		Name:            "ice-hockey",
+		WPWeight:        0.25,
+		OWPWeight:       0.21,
+		OOWPWeight:      0.54,
+		WinValue:        1.0,
+		TieValue:        0.5,
+		LossValue:       0.0,
+		OWPExcludesTeam: true,
+		Decisions:       DecisionRules{DecisionShootout: CreditTie},
+	}
+
+	// ClassicFormula is the original men's basketball formula, which weighted the elements 40/40/20.
+	ClassicFormula = Formula{
+		Name:            "classic",
+		WPWeight:        0.40,
+		OWPWeight:       0.40,
+		OOWPWeight:      0.20,
+		WinValue:        1.0,
+		TieValue:        0.5,
+		LossValue:       0.0,
+		OWPExcludesTeam: true,
+	}
+
+	// DefaultFormula is the formula used by a new schedule.
+	DefaultFormula = SoccerFormula
+)
+
+// formulas holds the presets that can be looked up by name.
+var formulas = newFormulaRegistry(
+	SoccerFormula,
+	BaseballFormula,
+	SoftballFormula,
+	BasketballFormula,
+	VolleyballFormula,
+	FieldHockeyFormula,
+	LacrosseFormula,
+	WaterPoloFormula,
+	IceHockeyFormula,
+	ClassicFormula,
+)
+
+// LookupFormula returns the preset formula with the specified name.
+func LookupFormula(name string) (Formula, error) {
+	formula, ok := formulas[name]
+	if !ok {
+		return Formula{}, fmt.Errorf("unknown formula %s", name)
+	}
+
+	return formula, nil
+}
+
+// FormulaNames returns the names of the preset formulas in alphabetical order.
+func FormulaNames() []string {
+	names := make([]string, 0, len(formulas))
+	for name := range formulas {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate checks that the weights and result values of the formula are usable.
+func (f Formula) Validate() error {
+	if !f.isFinite() {
+		return fmt.Errorf("the formula %s has a value that is not a finite number", f.Name)
+	}
+
+	if f.WPWeight < 0 || f.OWPWeight < 0 || f.OOWPWeight < 0 {
+		return fmt.Errorf("the formula %s has a negative element weight", f.Name)
+	}
+
+	if f.WPWeight+f.OWPWeight+f.OOWPWeight == 0 {
+		return fmt.Errorf("the formula %s has no element weights", f.Name)
+	}
+
+	if f.WinValue < f.TieValue || f.TieValue < f.LossValue {
+		return fmt.Errorf("the formula %s must value a win at least as much as a tie and a tie at least as much as a loss", f.Name)
+	}
+
+	if f.WeightedWP && !f.Weighting.validate() {
+		return fmt.Errorf("the formula %s has a negative location weight", f.Name)
+	}
+
+	if err := f.Decisions.Validate(); err != nil {
+		return fmt.Errorf("the formula %s has an invalid decision rule: %w", f.Name, err)
+	}
+
+	return nil
+}
+
+func (f Formula) isFinite() bool {
+	w := f.Weighting
+	values := []float64{
+		f.WPWeight, f.OWPWeight, f.OOWPWeight, f.WinValue, f.TieValue, f.LossValue,
+		w.HomeWin, w.AwayWin, w.NeutralWin, w.HomeLoss, w.AwayLoss, w.NeutralLoss, w.HomeTie, w.AwayTie, w.NeutralTie,
+	}
+
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Formula) named(name string) Formula {
+	f.Name = name
+	return f
+}
+
+func (f Formula) weighted(weighting Weighting) Formula {
+	f.WeightedWP = true
+	f.Weighting = weighting
+	return f
+}
+
+func newFormulaRegistry(presets ...Formula) map[string]Formula {
+	registry := make(map[string]Formula, len(presets))
+	for _, formula := range presets {
+		registry[formula.Name] = formula
+	}
+
+	return registry
+}
@@ -0,0 +1,359 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Formula", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("UConn,64,Kansas,57")
+		pSchedule.AddMatchFromString("UConn,82,Duke,68")
+		pSchedule.AddMatchFromString("Wisconsin,71,UConn,72")
+		pSchedule.AddMatchFromString("Kansas,69,UConn,62")
+		pSchedule.AddMatchFromString("Duke,81,Wisconsin,70")
+		pSchedule.AddMatchFromString("Wisconsin,52,Kansas,62")
+	})
+
+	AfterEach(func() {
+		pSchedule = nil
+	})
+
+	Describe("LookupFormula", func() {
+		It("should return a preset by name", func() {
+			// Act
+			formula, err := schedule.LookupFormula("ice-hockey")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(formula).To(Equal(schedule.IceHockeyFormula))
+		})
+
+		It("should return an error for an unknown formula", func() {
+			// Act
+			_, err := schedule.LookupFormula("curling")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("unknown formula curling"))
+		})
+	})
+
+	Describe("FormulaNames", func() {
+		It("should return every preset in alphabetical order", func() {
+			// Act
+			names := schedule.FormulaNames()
+
+			// Assert
+			Expect(names).To(HaveLen(10))
+			Expect(names[0]).To(Equal("baseball"))
+			Expect(names).To(ContainElement("soccer"))
+		})
+
+		It("should only name presets that pass validation", func() {
+			for _, name := range schedule.FormulaNames() {
+				formula, err := schedule.LookupFormula(name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(formula.Validate()).To(Succeed(), name)
+			}
+		})
+	})
+
+	Describe("Validate", func() {
+		It("should reject a value that is not a number", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.TieValue = math.NaN()
+
+			// Act
+			err := formula.Validate()
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the formula soccer has a value that is not a finite number"))
+		})
+
+		It("should reject a negative weight", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.OWPWeight = -0.5
+
+			// Act
+			err := formula.Validate()
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the formula soccer has a negative element weight"))
+		})
+
+		It("should reject a formula without weights", func() {
+			// Act
+			err := schedule.Formula{Name: "empty"}.Validate()
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the formula empty has no element weights"))
+		})
+
+		It("should reject a tie that is worth more than a win", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.TieValue = 1.5
+
+			// Act
+			err := formula.Validate()
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("SetFormula", func() {
+		It("should default to the soccer formula", func() {
+			// Act
+			formula := pSchedule.GetFormula()
+
+			// Assert
+			Expect(formula).To(Equal(schedule.SoccerFormula))
+		})
+
+		It("should reject an invalid formula", func() {
+			// Act
+			err := pSchedule.SetFormula(schedule.Formula{Name: "empty"})
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(pSchedule.GetFormula()).To(Equal(schedule.DefaultFormula))
+		})
+
+		It("should change the weights used by CalculateRPI", func() {
+			// Arrange
+			Expect(pSchedule.SetFormula(schedule.IceHockeyFormula)).To(Succeed())
+
+			// Act
+			rpi, err := pSchedule.CalculateRPI("UConn")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rpi).To(BeNumerically("~", 0.25*0.75+0.21*0.75+0.54*0.513889, 0.0001))
+		})
+
+		It("should change the weights used by CalculateAll", func() {
+			// Arrange
+			Expect(pSchedule.SetFormula(schedule.ClassicFormula)).To(Succeed())
+
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings.Find("UConn").RPI).To(BeNumerically("~", 0.4*0.75+0.4*0.75+0.2*0.513889, 0.0001))
+		})
+
+		It("should include the games against the rated team when the formula does not exclude them", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.OWPExcludesTeam = false
+			Expect(pSchedule.SetFormula(formula)).To(Succeed())
+
+			// Act
+			owp, err := pSchedule.CalculateOWP("Duke")
+
+			// Assert
+			// Duke's opponents are UConn (3-1) and Wisconsin (0-3).
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owp).To(BeNumerically("~", (0.75+0.0)/2, 0.0001))
+		})
+	})
+
+	Describe("result values", func() {
+		BeforeEach(func() {
+			pSchedule = schedule.NewSchedule()
+
+			pSchedule.AddMatchFromString("A,1,B,1")
+			pSchedule.AddMatchFromString("A,2,B,2")
+			pSchedule.AddMatchFromString("B,0,A,0")
+			pSchedule.AddMatchFromString("A,1,C,0")
+		})
+
+		It("should credit every tie with half a win", func() {
+			// Act
+			wp, err := pSchedule.CalculateWP("A", "")
+
+			// Assert
+			// 1 win and 3 ties
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wp).To(BeNumerically("~", 2.5/4, 1e-12))
+		})
+
+		It("should use the configured value of a tie", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.TieValue = 1.0 / 3.0
+			Expect(pSchedule.SetFormula(formula)).To(Succeed())
+
+			// Act
+			wp, err := pSchedule.CalculateWP("A", "")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wp).To(BeNumerically("~", 2.0/4, 1e-12))
+		})
+	})
+
+	Describe("weighted WP", func() {
+		BeforeEach(func() {
+			factory := match.NewFactory(match.NewBuilder())
+
+			pSchedule = schedule.NewSchedule()
+
+			// Team A wins at home, loses on the road and wins at a neutral site.
+			pSchedule.AddMatch(factory.CreateWithRandomDate("Team A", 2, "Team B", 1))
+			pSchedule.AddMatch(factory.CreateWithRandomDate("Team C", 3, "Team A", 0))
+			neutral := factory.CreateWithRandomDate("Team D", 0, "Team A", 1)
+			neutral.Neutral = true
+			pSchedule.AddMatch(neutral)
+		})
+
+		It("should not weight results by default", func() {
+			// Act
+			wp, err := pSchedule.CalculateWP("Team A", "")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wp).To(BeNumerically("~", 2.0/3.0, 1e-12))
+		})
+
+		It("should weight results by location", func() {
+			// Arrange
+			Expect(pSchedule.SetFormula(schedule.BasketballFormula)).To(Succeed())
+
+			// Act
+			wp, err := pSchedule.CalculateWP("Team A", "")
+
+			// Assert
+			// (0.6 + 1.0) weighted wins out of (0.6 + 1.0 + 0.6) weighted games
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wp).To(BeNumerically("~", 1.6/2.2, 1e-12))
+		})
+
+		It("should use the configured weights", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.WeightedWP = true
+			formula.Weighting = schedule.BaseballWeighting
+			formula.Weighting.NeutralWin = 2.0
+			Expect(pSchedule.SetFormula(formula)).To(Succeed())
+
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			// (0.7 + 2.0) weighted wins out of (0.7 + 2.0 + 0.7) weighted games
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings.Find("Team A").WP).To(BeNumerically("~", 2.7/3.4, 1e-12))
+		})
+
+		It("should not weight the opponents' winning percentages", func() {
+			// Arrange
+			Expect(pSchedule.SetFormula(schedule.BasketballFormula)).To(Succeed())
+
+			// Act
+			owp, err := pSchedule.CalculateOWP("Team B")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owp).To(BeNumerically("~", 0.5, 1e-12))
+		})
+
+		It("should reject a negative weight", func() {
+			// Arrange
+			formula := schedule.BasketballFormula
+			formula.Weighting.AwayLoss = -1
+
+			// Act
+			err := pSchedule.SetFormula(formula)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the formula basketball has a negative location weight"))
+		})
+	})
+
+	Describe("decision rules", func() {
+		BeforeEach(func() {
+			pSchedule = schedule.NewSchedule()
+
+			pSchedule.AddMatchFromString("2023-09-01,Team A,1,Team B,1 (4-3 PK)")
+			pSchedule.AddMatchFromString("2023-09-05,Team A,2,Team C,1 (OT)")
+			pSchedule.AddMatchFromString("2023-09-09,Team C,1,Team A,0 (FF)")
+		})
+
+		It("should count a shootout as a tie in soccer", func() {
+			// Act
+			ties, err := pSchedule.GetTiesForTeam("Team A", "")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ties).To(Equal(1))
+		})
+
+		It("should credit the shootout winner when the formula says so", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.Decisions = match.DecisionRules{match.DecisionShootout: match.CreditShootoutWinner}
+			Expect(pSchedule.SetFormula(formula)).To(Succeed())
+
+			// Act
+			wins, err := pSchedule.GetWinsForTeam("Team A", "")
+			Expect(err).NotTo(HaveOccurred())
+			losses, err := pSchedule.GetLossesForTeam("Team B", "")
+			Expect(err).NotTo(HaveOccurred())
+			wp, err := pSchedule.CalculateWP("Team A", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			Expect(wins).To(Equal(2))
+			Expect(losses).To(Equal(1))
+			Expect(wp).To(BeNumerically("~", 2.0/3.0, 1e-12))
+		})
+
+		It("should leave excluded matches out of the ratings", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.Decisions = match.DecisionRules{match.DecisionForfeit: match.CreditExcluded}
+			Expect(pSchedule.SetFormula(formula)).To(Succeed())
+
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings.Find("Team A").Wins).To(Equal(1))
+			Expect(ratings.Find("Team A").Losses).To(Equal(0))
+			Expect(ratings.Find("Team A").Ties).To(Equal(1))
+			Expect(ratings.Find("Team C").Wins).To(Equal(0))
+		})
+
+		It("should reject an invalid rule", func() {
+			// Arrange
+			formula := schedule.DefaultFormula
+			formula.Decisions = match.DecisionRules{match.Decision(42): match.CreditTie}
+
+			// Act
+			err := pSchedule.SetFormula(formula)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the formula soccer has an invalid decision rule: unknown decision 42"))
+		})
+	})
+})
@@ -0,0 +1,208 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// day returns the calendar date of t, in t's own location, as midnight UTC so that dates recorded in
+// different time zones compare by the day they were played on.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// playedBy reports whether the match was played on or before the cutoff's calendar date.  Matches
+// without a date are treated as played before every cutoff, and fixtures as never played.
+func playedBy(match *Match, cutoff time.Time) bool {
+	return match.IsPlayed() && !day(match.Date).After(day(cutoff))
+}
+
+// AsOf returns a schedule with the matches played on or before the cutoff's calendar date, which rates
+// the teams as they stood at the end of that day, leaving out fixtures that haven't been played.  The
+// schedule shares the formula, the registry and the matches themselves, keeping their IDs, but adding
+// or removing matches changes only one schedule.
+func (s *Schedule) AsOf(cutoff time.Time) *Schedule {
+	v := s.snapshot()
+
+	store := NewMemoryStore()
+	for _, match := range v.asOf(cutoff) {
+		_ = store.Add(match)
+	}
+
+	past := NewScheduleWithStore(store)
+	past.formula = v.formula
+	past.registry = v.registry
+
+	return past
+}
+
+// asOf returns the matches in the snapshot played on or before the cutoff.
+func (v *snapshot) asOf(cutoff time.Time) []*Match {
+	var matches []*Match
+	for _, match := range v.matches {
+		if playedBy(match, cutoff) {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// CalculateAllAsOf calculates the WP, OWP, OOWP and RPI of every eligible team over the matches played
+// on or before the cutoff's calendar date.
+func (s *Schedule) CalculateAllAsOf(cutoff time.Time) (Ratings, error) {
+	v := s.snapshot()
+	v.matches = v.asOf(cutoff)
+
+	return v.all(), nil
+}
+
+// Standing is a team's place in a week's ranking and how it moved since the week before.
+type Standing struct {
+	*Rating
+
+	Rank int
+
+	// PreviousRank is the team's rank the week before, or zero when it wasn't ranked then, and
+	// PreviousRPI is its RPI the week before, or NaN when it wasn't ranked.
+	PreviousRank int
+	PreviousRPI  float64
+}
+
+// IsNew reports whether the team wasn't ranked the week before.
+func (s *Standing) IsNew() bool {
+	return s.PreviousRank == 0
+}
+
+// RankChange returns how many places the team moved up since the week before, which is negative when it
+// moved down and zero when it is new.
+func (s *Standing) RankChange() int {
+	if s.IsNew() {
+		return 0
+	}
+
+	return s.PreviousRank - s.Rank
+}
+
+// RPIChange returns how much the team's RPI changed since the week before, which is NaN when it is new.
+func (s *Standing) RPIChange() float64 {
+	return s.RPI - s.PreviousRPI
+}
+
+// Week is the ranking of every team at the end of a week of the season.
+type Week struct {
+	// Number counts the weeks from one, and Start and End are the first and last days of the week.
+	Number int
+	Start  time.Time
+	End    time.Time
+
+	// Matches is the number of matches played by the end of the week.
+	Matches int
+
+	Standings []*Standing
+}
+
+// Find returns the standing of the specified team or nil if the team wasn't ranked that week.
+func (w *Week) Find(teamName string) *Standing {
+	for _, standing := range w.Standings {
+		if standing.Team == teamName {
+			return standing
+		}
+	}
+
+	return nil
+}
+
+// History is the weekly rankings of a season in order.
+type History []*Week
+
+// History ranks every team at the end of each week of the season, from the week of the first dated
+// match to the week of the last.  Weeks end on weekEnd, so a newsletter published on Mondays would use
+// time.Sunday.  Each standing records the team's rank and RPI the week before.
+func (s *Schedule) History(weekEnd time.Weekday) (History, error) {
+	v := s.snapshot()
+
+	first, last, ok := v.season()
+	if !ok {
+		return nil, fmt.Errorf("no matches found")
+	}
+
+	end := first.AddDate(0, 0, (int(weekEnd)-int(first.Weekday())+7)%7)
+
+	var history History
+	var previous *Week
+	for number := 1; ; number++ {
+		week := v.week(end)
+		week.Number = number
+		week.Start = end.AddDate(0, 0, -6)
+		week.compare(previous)
+
+		history = append(history, week)
+		previous = week
+
+		if !end.Before(last) {
+			break
+		}
+
+		end = end.AddDate(0, 0, 7)
+	}
+
+	return history, nil
+}
+
+// season returns the calendar dates of the first and last dated matches that have been played.
+func (v *snapshot) season() (time.Time, time.Time, bool) {
+	var first, last time.Time
+	found := false
+
+	for _, match := range v.matches {
+		if match.Date.IsZero() || !match.IsPlayed() {
+			continue
+		}
+
+		d := day(match.Date)
+		if !found || d.Before(first) {
+			first = d
+		}
+		if !found || d.After(last) {
+			last = d
+		}
+
+		found = true
+	}
+
+	return first, last, found
+}
+
+// week ranks the teams over the matches played by the end of the week.
+func (v *snapshot) week(end time.Time) *Week {
+	past := &snapshot{matches: v.asOf(end), formula: v.formula, registry: v.registry}
+
+	ratings := past.all()
+	ratings.SortByRPI()
+
+	week := &Week{End: end, Matches: len(past.matches), Standings: make([]*Standing, 0, len(ratings))}
+	for i, rating := range ratings {
+		week.Standings = append(week.Standings, &Standing{Rating: rating, Rank: i + 1})
+	}
+
+	return week
+}
+
+// compare records each team's rank and RPI in the previous week.
+func (w *Week) compare(previous *Week) {
+	for _, standing := range w.Standings {
+		standing.PreviousRPI = math.NaN()
+		if previous == nil {
+			continue
+		}
+
+		if before := previous.Find(standing.Team); before != nil {
+			standing.PreviousRank = before.Rank
+			standing.PreviousRPI = before.RPI
+		}
+	}
+}
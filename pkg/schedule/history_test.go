@@ -0,0 +1,177 @@
+package schedule_test
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("History", func() {
+	var pSchedule *schedule.Schedule
+
+	date := func(value string) time.Time {
+		t, err := time.Parse("2006-01-02", value)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		for _, line := range []string{
+			"2023-11-06,UConn,64,Kansas,57",
+			"2023-11-10,UConn,82,Duke,68",
+			"2023-11-20,Kansas,69,UConn,62",
+			"2023-11-24,Duke,81,Wisconsin,70",
+			"2023-11-28,Wisconsin,52,Kansas,62",
+		} {
+			Expect(pSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+
+		// Played in the evening in New York, which is already the next day in UTC.
+		Expect(pSchedule.AddMatch(&match.Match{
+			Date: time.Date(2023, 11, 14, 21, 0, 0, 0, time.FixedZone("EST", -5*60*60)),
+			Home: match.Status{Name: "Wisconsin", Score: 71},
+			Away: match.Status{Name: "UConn", Score: 72},
+		})).To(Succeed())
+	})
+
+	Describe("AsOf", func() {
+		It("should keep the matches played on or before the cutoff date", func() {
+			// Act
+			past := pSchedule.AsOf(date("2023-11-14"))
+
+			// Assert
+			Expect(past.GetTotalMatchesPlayed()).To(Equal(3))
+			Expect(past.GetFormula()).To(Equal(pSchedule.GetFormula()))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(6))
+
+			wins, err := past.GetWinsForTeam("UConn", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wins).To(Equal(3))
+		})
+
+		It("should keep the match IDs", func() {
+			// Act
+			past := pSchedule.AsOf(date("2023-11-10"))
+
+			// Assert
+			m, err := past.GetMatch("2023-11-10-uconn-duke")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Home.Score).To(Equal(82))
+		})
+
+		It("should rate the teams as they stood on the cutoff date", func() {
+			// Arrange
+			expected, err := pSchedule.AsOf(date("2023-11-20")).CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings, err := pSchedule.CalculateAllAsOf(date("2023-11-20"))
+
+			// Assert
+			// Some elements are still undefined, so the ratings are compared as text because NaN isn't
+			// equal to itself.
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(len(expected)))
+			for i, rating := range ratings {
+				Expect(fmt.Sprint(*rating)).To(Equal(fmt.Sprint(*expected[i])))
+			}
+			Expect(ratings.Find("Kansas").Wins).To(Equal(1))
+		})
+	})
+
+	Describe("History", func() {
+		It("should rank the teams at the end of each week", func() {
+			// Act
+			history, err := pSchedule.History(time.Sunday)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(4))
+
+			Expect(history[0].Number).To(Equal(1))
+			Expect(history[0].Start).To(Equal(date("2023-11-06")))
+			Expect(history[0].End).To(Equal(date("2023-11-12")))
+			Expect(history[3].End).To(Equal(date("2023-12-03")))
+
+			var matches []int
+			for _, week := range history {
+				matches = append(matches, week.Matches)
+			}
+			Expect(matches).To(Equal([]int{2, 3, 5, 6}))
+		})
+
+		It("should end the last week with the full ranking", func() {
+			// Arrange
+			expected, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+			expected.SortByRPI()
+
+			// Act
+			history, err := pSchedule.History(time.Sunday)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			last := history[len(history)-1]
+			Expect(last.Standings).To(HaveLen(len(expected)))
+			for i, standing := range last.Standings {
+				Expect(standing.Rank).To(Equal(i + 1))
+				Expect(standing.Rating).To(Equal(expected[i]))
+			}
+		})
+
+		It("should record each team's movement since the week before", func() {
+			// Act
+			history, err := pSchedule.History(time.Sunday)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, standing := range history[0].Standings {
+				Expect(standing.IsNew()).To(BeTrue())
+				Expect(standing.RankChange()).To(Equal(0))
+				Expect(math.IsNaN(standing.RPIChange())).To(BeTrue())
+			}
+
+			Expect(history[1].Find("Wisconsin").IsNew()).To(BeTrue())
+
+			for i := 1; i < len(history); i++ {
+				for _, standing := range history[i].Standings {
+					before := history[i-1].Find(standing.Team)
+					if before == nil {
+						continue
+					}
+
+					Expect(standing.PreviousRank).To(Equal(before.Rank))
+					Expect(standing.RankChange()).To(Equal(before.Rank - standing.Rank))
+				}
+			}
+		})
+
+		It("should end the weeks on the requested day", func() {
+			// Act
+			history, err := pSchedule.History(time.Wednesday)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history[0].End).To(Equal(date("2023-11-08")))
+			Expect(history[0].End.Weekday()).To(Equal(time.Wednesday))
+			Expect(history[len(history)-1].End).To(Equal(date("2023-11-29")))
+		})
+
+		It("should fail without matches", func() {
+			// Act
+			_, err := schedule.NewSchedule().History(time.Sunday)
+
+			// Assert
+			Expect(err).To(MatchError("no matches found"))
+		})
+	})
+})
@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/team"
+)
+
+// index records the ID of every match in the store, assigning IDs to matches that don't have one.
+func (s *Schedule) index() {
+	s.ids = make(map[string]*Match)
+	for _, match := range s.store.Matches() {
+		if match.ID == "" || s.ids[match.ID] != nil {
+			match.ID = s.newMatchID(match)
+		}
+
+		s.ids[match.ID] = match
+	}
+}
+
+// newMatchID returns an unused ID built from the match's date and teams, such as
+// "2023-11-06-uconn-kansas".  A number is added when the teams meet more than once on the same day.
+func (s *Schedule) newMatchID(match *Match) string {
+	base := fmt.Sprintf("%s-%s-%s", match.Date.Format("2006-01-02"), team.Slug(match.Home.Name), team.Slug(match.Away.Name))
+
+	id := base
+	for n := 2; s.ids[id] != nil; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	return id
+}
+
+// GetMatch returns the match with the specified ID.
+func (s *Schedule) GetMatch(id string) (*Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lookup(id)
+}
+
+func (s *Schedule) lookup(id string) (*Match, error) {
+	match, ok := s.ids[id]
+	if !ok {
+		return nil, fmt.Errorf("no match with id %s", id)
+	}
+
+	return match, nil
+}
+
+// RemoveMatchByID removes the match with the specified ID and returns it.
+func (s *Schedule) RemoveMatchByID(id string) (*Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, err := s.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.store.Remove(match); err != nil {
+		return nil, err
+	}
+
+	delete(s.ids, id)
+
+	return match, nil
+}
+
+// ReplaceMatch puts a copy of the replacement in place of the match with the specified ID and returns
+// the match it replaced.  The copy takes over the ID and the replacement itself is unchanged.  A match
+// that is already in the schedule can't be the replacement.
+func (s *Schedule) ReplaceMatch(id string, replacement *Match) (*Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, err := s.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if replacement == nil {
+		return nil, fmt.Errorf("the specified match is nil")
+	}
+
+	if slices.Contains(s.store.Matches(), replacement) {
+		return nil, fmt.Errorf("the replacement is already in the schedule as %s", replacement.ID)
+	}
+
+	stored := replacement.Clone()
+	stored.ID = id
+	if err = s.store.Replace(match, stored); err != nil {
+		return nil, err
+	}
+
+	s.ids[id] = stored
+
+	return match, nil
+}
+
+// UpdateMatch changes a copy of the match with the specified ID and puts it in place of the original,
+// so that calculations already running never see a half-changed match.  It returns the updated match.
+// Nothing changes when update returns an error.
+func (s *Schedule) UpdateMatch(id string, update func(match *Match) error) (*Match, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match, err := s.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := match.Clone()
+	if err = update(updated); err != nil {
+		return nil, err
+	}
+
+	updated.ID = id
+	if err = s.store.Replace(match, updated); err != nil {
+		return nil, err
+	}
+
+	s.ids[id] = updated
+
+	return updated, nil
+}
@@ -0,0 +1,175 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Match IDs", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+		Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,70,Kansas,71")).To(Succeed())
+		Expect(pSchedule.AddMatchFromString("2023-11-10,Texas A&M,82,Duke,68")).To(Succeed())
+	})
+
+	It("should give each match an ID built from its date and teams", func() {
+		// Act
+		var ids []string
+		for _, m := range pSchedule.GetMatches() {
+			ids = append(ids, m.ID)
+		}
+
+		// Assert
+		Expect(ids).To(Equal([]string{
+			"2023-11-06-uconn-kansas",
+			"2023-11-06-uconn-kansas-2",
+			"2023-11-10-texas-a-m-duke",
+		}))
+	})
+
+	It("should keep the ID of a match that has one", func() {
+		// Arrange
+		m := match.NewMatchFromString("2023-11-12,Duke,1,Kansas,0")
+		m.ID = "final"
+
+		// Act
+		err := pSchedule.AddMatch(m)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pSchedule.GetMatch("final")).To(BeIdenticalTo(m))
+	})
+
+	It("should reject a match whose ID is taken", func() {
+		// Arrange
+		m := match.NewMatchFromString("2023-11-12,Duke,1,Kansas,0")
+		m.ID = "2023-11-06-uconn-kansas"
+
+		// Act
+		err := pSchedule.AddMatch(m)
+
+		// Assert
+		Expect(err).To(MatchError("a match with id 2023-11-06-uconn-kansas already exists"))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(3))
+	})
+
+	It("should give IDs to the matches already in a store", func() {
+		// Arrange
+		first := match.NewMatchFromString("2023-11-06,UConn,64,Kansas,57")
+		second := match.NewMatchFromString("2023-11-10,Duke,1,Kansas,0")
+		second.ID = "final"
+
+		// Act
+		s := schedule.NewScheduleWithStore(schedule.NewMemoryStore(first, second))
+
+		// Assert
+		Expect(first.ID).To(Equal("2023-11-06-uconn-kansas"))
+		Expect(s.GetMatch("final")).To(BeIdenticalTo(second))
+	})
+
+	It("should return an error for an unknown ID", func() {
+		// Act
+		m, err := pSchedule.GetMatch("2023-11-07-uconn-kansas")
+
+		// Assert
+		Expect(m).To(BeNil())
+		Expect(err).To(MatchError("no match with id 2023-11-07-uconn-kansas"))
+	})
+
+	It("should remove a match by ID", func() {
+		// Act
+		removed, err := pSchedule.RemoveMatchByID("2023-11-06-uconn-kansas-2")
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed.Away.Score).To(Equal(71))
+		Expect(pSchedule.GetRecord("Kansas")).To(Equal(schedule.Record{Losses: 1}))
+
+		_, err = pSchedule.GetMatch("2023-11-06-uconn-kansas-2")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should forget the ID of a match removed by pointer", func() {
+		// Arrange
+		m, err := pSchedule.GetMatch("2023-11-10-texas-a-m-duke")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Act
+		Expect(pSchedule.RemoveMatch(m)).To(Succeed())
+
+		// Assert
+		Expect(pSchedule.AddMatchFromString("2023-11-10,Texas A&M,60,Duke,68")).To(Succeed())
+		Expect(pSchedule.GetMatches()[2].ID).To(Equal("2023-11-10-texas-a-m-duke"))
+	})
+
+	It("should replace a match in place", func() {
+		// Arrange
+		replacement := match.NewMatchFromString("2023-11-07,UConn,64,Kansas,65")
+
+		// Act
+		previous, err := pSchedule.ReplaceMatch("2023-11-06-uconn-kansas", replacement)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(previous.Away.Score).To(Equal(57))
+		Expect(replacement.ID).To(BeEmpty())
+		Expect(pSchedule.GetMatches()[0]).NotTo(BeIdenticalTo(replacement))
+		Expect(pSchedule.GetMatches()[0].ID).To(Equal("2023-11-06-uconn-kansas"))
+		Expect(pSchedule.GetMatches()[0].Away.Score).To(Equal(65))
+		Expect(pSchedule.GetRecord("UConn")).To(Equal(schedule.Record{Losses: 2}))
+	})
+
+	It("should reject a replacement that is already in the schedule", func() {
+		// Arrange
+		other, err := pSchedule.GetMatch("2023-11-10-texas-a-m-duke")
+		Expect(err).NotTo(HaveOccurred())
+		before := pSchedule.GetMatches()
+
+		// Act
+		previous, err := pSchedule.ReplaceMatch("2023-11-06-uconn-kansas", other)
+
+		// Assert
+		Expect(previous).To(BeNil())
+		Expect(err).To(MatchError("the replacement is already in the schedule as 2023-11-10-texas-a-m-duke"))
+		Expect(pSchedule.GetMatches()).To(Equal(before))
+		Expect(pSchedule.GetMatch("2023-11-10-texas-a-m-duke")).To(BeIdenticalTo(other))
+	})
+
+	It("should update a copy of a match", func() {
+		// Arrange
+		original, err := pSchedule.GetMatch("2023-11-06-uconn-kansas")
+		Expect(err).NotTo(HaveOccurred())
+
+		// Act
+		updated, err := pSchedule.UpdateMatch("2023-11-06-uconn-kansas", func(m *match.Match) error {
+			return m.SetScore("1-1 (OT)")
+		})
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(original.ToString()).To(Equal("UConn,64,Kansas,57"))
+		Expect(updated.ToString()).To(Equal("UConn,1,Kansas,1 (OT)"))
+		Expect(pSchedule.GetMatch("2023-11-06-uconn-kansas")).To(BeIdenticalTo(updated))
+		Expect(pSchedule.GetRecord("UConn")).To(Equal(schedule.Record{Losses: 1, Ties: 1}))
+	})
+
+	It("should leave a match unchanged when the update fails", func() {
+		// Act
+		updated, err := pSchedule.UpdateMatch("2023-11-06-uconn-kansas", func(m *match.Match) error {
+			m.Home.Score = 0
+			return fmt.Errorf("vacated")
+		})
+
+		// Assert
+		Expect(updated).To(BeNil())
+		Expect(err).To(MatchError("vacated"))
+		Expect(pSchedule.GetMatches()[0].Home.Score).To(Equal(64))
+	})
+})
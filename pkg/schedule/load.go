@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"io"
+	"maps"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+)
+
+// LoadCSV reads every match in a CSV file into the schedule.  When opts.Teams is nil and the schedule
+// has a registry, team names are resolved through the registry.  Nothing is added unless every record
+// parses and every match can be added; when a record is invalid the error is an importer.Errors listing
+// each invalid record with its line number.
+func (s *Schedule) LoadCSV(r io.Reader, opts importer.Options) error {
+	if registry := s.GetRegistry(); opts.Teams == nil && registry != nil {
+		opts.Teams = registry
+	}
+
+	// The file is read before the lock is taken so that readers aren't held up while it is parsed.
+	matches, errs := importer.ReadCSV(r, opts)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkNewIDs(matches, nil); err != nil {
+		return err
+	}
+
+	ids := maps.Clone(s.ids)
+	for i, m := range matches {
+		if err := s.add(m); err != nil {
+			s.rollback(matches[:i], nil, ids)
+			return err
+		}
+	}
+
+	return nil
+}
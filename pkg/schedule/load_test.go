@@ -0,0 +1,113 @@
+package schedule_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// probingReader calls probe before each read so a test can query a schedule while it is reading.
+type probingReader struct {
+	io.Reader
+	probe func()
+}
+
+func (p *probingReader) Read(b []byte) (int, error) {
+	p.probe()
+	return p.Reader.Read(b)
+}
+
+var _ = Describe("LoadCSV", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+	})
+
+	It("should add every match in the file", func() {
+		// Arrange
+		input := "date,home,home score,away,away score\n2023-09-01,Duke,2,UNC,1\n2023-09-05,\"UNC, Chapel Hill\",0,Duke,1\n"
+
+		// Act
+		err := pSchedule.LoadCSV(strings.NewReader(input), importer.Options{})
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(2))
+		Expect(pSchedule.GetTeams()).To(Equal([]string{"Duke", "UNC", "UNC, Chapel Hill"}))
+	})
+
+	It("should not hold the schedule's lock while reading the file", func() {
+		// Arrange
+		Expect(pSchedule.AddMatchFromString("2023-08-25,Duke,1,UNC,1")).To(Succeed())
+		var seen []int
+		r := &probingReader{
+			Reader: strings.NewReader("2023-09-01,Duke,2,UNC,1\n"),
+			probe:  func() { seen = append(seen, pSchedule.GetTotalMatchesPlayed()) },
+		}
+
+		// Act
+		err := pSchedule.LoadCSV(r, importer.Options{})
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seen).NotTo(BeEmpty())
+		Expect(seen[0]).To(Equal(1))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(2))
+	})
+
+	It("should add nothing when a record is invalid", func() {
+		// Arrange
+		input := "2023-09-01,Duke,2,UNC,1\n2023-09-05,UNC,0,Duke\n"
+
+		// Act
+		err := pSchedule.LoadCSV(strings.NewReader(input), importer.Options{FileName: "results.csv"})
+
+		// Assert
+		Expect(err).To(HaveOccurred())
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(0))
+
+		var errs importer.Errors
+		Expect(errors.As(err, &errs)).To(BeTrue())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].FileName).To(Equal("results.csv"))
+		Expect(errs[0].Line).To(Equal(2))
+	})
+
+	It("should resolve team names through the schedule's registry", func() {
+		// Arrange
+		registry := team.NewRegistry()
+		Expect(registry.Register(&team.Team{Name: "North Carolina", Aliases: []string{"UNC"}})).To(Succeed())
+		Expect(registry.Register(&team.Team{Name: "Duke"})).To(Succeed())
+		pSchedule.SetRegistry(registry)
+		input := "2023-09-01,Duke,2,UNC,1\n"
+
+		// Act
+		err := pSchedule.LoadCSV(strings.NewReader(input), importer.Options{})
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pSchedule.GetTeams()).To(Equal([]string{"Duke", "North Carolina"}))
+	})
+
+	It("should add nothing when the store can't add every match", func() {
+		// Arrange
+		pSchedule = schedule.NewScheduleWithStore(&limitedStore{MemoryStore: schedule.NewMemoryStore(), adds: 1})
+		input := "2023-09-01,Duke,2,UNC,1\n2023-09-05,UNC,0,Duke,1\n"
+
+		// Act
+		err := pSchedule.LoadCSV(strings.NewReader(input), importer.Options{})
+
+		// Assert
+		Expect(err).To(MatchError("the store is full"))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(0))
+		_, err = pSchedule.GetMatch("2023-09-01-duke-unc")
+		Expect(err).To(HaveOccurred())
+	})
+})
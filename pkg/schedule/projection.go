@@ -0,0 +1,229 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Outcomes are the results to use for fixtures that haven't been played, keyed by match ID.  Each is a
+// score in the form home-away, such as "2-1", which may be followed by a decision annotation such as
+// "(OT)" or "(4-3 PK)".
+type Outcomes map[string]string
+
+// ParseOutcomes reads an outcomes file, which holds one outcome per line in the form
+//
+//	<id> <home>-<away> [annotation]
+//
+// Blank lines and lines starting with # are ignored.
+func ParseOutcomes(r io.Reader) (Outcomes, error) {
+	outcomes := make(Outcomes)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		id, score, _ := strings.Cut(text, " ")
+		score = strings.TrimSpace(score)
+		if score == "" {
+			return nil, fmt.Errorf("line %d: %s requires a score", line, id)
+		}
+
+		if err := new(Match).SetScore(score); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if _, ok := outcomes[id]; ok {
+			return nil, fmt.Errorf("line %d: a second outcome for %s", line, id)
+		}
+
+		outcomes[id] = score
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// Prediction is the result a projection gave a fixture.
+type Prediction struct {
+	// Match is a copy of the fixture with its projected result, so the schedule's fixture is unchanged.
+	Match *Match
+
+	// Supplied reports whether the result came from the outcomes rather than from the current RPI.
+	Supplied bool
+}
+
+// ProjectedRating is a team's rating at the end of the season when every fixture has been given a
+// result.
+type ProjectedRating struct {
+	*Rating
+
+	// CurrentRPI is the team's RPI over the matches played so far, or NaN when it isn't rated yet.
+	CurrentRPI float64
+
+	// Remaining is the number of fixtures the team still has to play.
+	Remaining int
+}
+
+// RPIChange returns how much the team's RPI changes by the end of the season, which is NaN when it
+// isn't rated yet.
+func (r *ProjectedRating) RPIChange() float64 {
+	return r.RPI - r.CurrentRPI
+}
+
+// ProjectedRatings is the projected rating of every team.
+type ProjectedRatings []*ProjectedRating
+
+// Find returns the projected rating for the specified team or nil if the team has no rating.
+func (r ProjectedRatings) Find(teamName string) *ProjectedRating {
+	for _, rating := range r {
+		if rating.Team == teamName {
+			return rating
+		}
+	}
+
+	return nil
+}
+
+// SortByRPI orders the ratings from the highest projected RPI to the lowest.  Equal ratings are ordered
+// by team name and ratings with an undefined RPI are placed last.
+func (r ProjectedRatings) SortByRPI() {
+	sortByValue(r, func(rating *ProjectedRating) (string, float64) {
+		return rating.Team, rating.RPI
+	})
+}
+
+// Projection is the end of a season projected from the matches played so far.
+type Projection struct {
+	// Predictions holds the result given to each fixture that is still to be played, in schedule order.
+	Predictions []*Prediction
+
+	Ratings ProjectedRatings
+}
+
+// Project fills in every scheduled and postponed fixture with a result and rates the teams over the
+// completed season.  A fixture named in the outcomes is given that result; any other is won 1-0 by the
+// team with the higher current RPI, or drawn 0-0 when neither team is favored.  Cancelled fixtures are
+// left out.  An outcome must name a fixture that is still to be played.
+func (s *Schedule) Project(outcomes Outcomes) (*Projection, error) {
+	v := s.snapshot()
+
+	if err := v.checkOutcomes(outcomes); err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]float64)
+	for _, rating := range v.all() {
+		current[rating.Team] = rating.RPI
+	}
+
+	projection := &Projection{}
+	season := &snapshot{matches: v.played(), formula: v.formula, registry: v.registry}
+	remaining := make(map[string]int)
+
+	for _, fixture := range v.matches {
+		if !fixture.IsPending() {
+			continue
+		}
+
+		prediction, err := predict(fixture, outcomes, current)
+		if err != nil {
+			return nil, err
+		}
+
+		projection.Predictions = append(projection.Predictions, prediction)
+		season.matches = append(season.matches, prediction.Match)
+		remaining[fixture.Home.Name]++
+		remaining[fixture.Away.Name]++
+	}
+
+	for _, rating := range season.all() {
+		currentRPI, ok := current[rating.Team]
+		if !ok {
+			currentRPI = math.NaN()
+		}
+
+		projection.Ratings = append(projection.Ratings, &ProjectedRating{
+			Rating:     rating,
+			CurrentRPI: currentRPI,
+			Remaining:  remaining[rating.Team],
+		})
+	}
+
+	return projection, nil
+}
+
+// checkOutcomes returns an error when an outcome names a match that isn't a fixture still to be played.
+func (v *snapshot) checkOutcomes(outcomes Outcomes) error {
+	pending := make(map[string]bool)
+	known := make(map[string]bool)
+	for _, match := range v.matches {
+		known[match.ID] = true
+		pending[match.ID] = match.IsPending()
+	}
+
+	for id := range outcomes {
+		switch {
+		case !known[id]:
+			return fmt.Errorf("no match with id %s", id)
+		case !pending[id]:
+			return fmt.Errorf("match %s isn't a fixture to be played", id)
+		}
+	}
+
+	return nil
+}
+
+// predict gives a copy of the fixture its supplied outcome or, without one, a win for the favorite.
+func predict(fixture *Match, outcomes Outcomes, current map[string]float64) (*Prediction, error) {
+	prediction := &Prediction{Match: fixture.Clone()}
+
+	if score, ok := outcomes[fixture.ID]; ok {
+		if err := prediction.Match.SetScore(score); err != nil {
+			return nil, fmt.Errorf("match %s: %w", fixture.ID, err)
+		}
+
+		prediction.Supplied = true
+
+		return prediction, nil
+	}
+
+	switch favorite(current, fixture.Home.Name, fixture.Away.Name) {
+	case 1:
+		prediction.Match.Home.Score = 1
+	case -1:
+		prediction.Match.Away.Score = 1
+	}
+
+	prediction.Match.State = StateCompleted
+
+	return prediction, nil
+}
+
+// favorite returns 1 when the home team's RPI is higher, -1 when the away team's is, and 0 when they
+// are equal.  A team that isn't rated is the underdog, and two teams that aren't rated are equal.
+func favorite(current map[string]float64, home, away string) int {
+	homeRPI, homeRated := current[home]
+	awayRPI, awayRated := current[away]
+	homeRated = homeRated && !math.IsNaN(homeRPI)
+	awayRated = awayRated && !math.IsNaN(awayRPI)
+
+	switch {
+	case homeRated && (!awayRated || homeRPI > awayRPI):
+		return 1
+	case awayRated && (!homeRated || awayRPI > homeRPI):
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,259 @@
+package schedule_test
+
+import (
+	"math"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Projection", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		for _, line := range []string{
+			"2023-09-01,A,2,B,0",
+			"2023-09-02,C,1,D,0",
+			"2023-09-03,A,1,C,0",
+			"2023-09-04,B,1,D,1",
+			"2023-09-05,D,2,E,0",
+			"2023-09-06,E,1,B,0",
+			"2023-09-07,C,2,E,2",
+			"2023-09-10,D,,A,",
+			"2023-09-11,B,,C,",
+			"2023-09-12,E,,A,",
+		} {
+			Expect(pSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+	})
+
+	Describe("fixtures", func() {
+		It("should store fixtures without rating them", func() {
+			// Arrange
+			played := schedule.NewSchedule()
+			for _, m := range pSchedule.GetMatches() {
+				if m.IsPlayed() {
+					Expect(played.AddMatch(m.Clone())).To(Succeed())
+				}
+			}
+
+			expected, err := played.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pSchedule.GetMatches()).To(HaveLen(10))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(7))
+			Expect(ratings).To(Equal(expected))
+
+			ties, err := pSchedule.GetTiesForTeam("A", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ties).To(Equal(0))
+		})
+
+		It("should rate a fixture once its result is entered", func() {
+			// Act
+			_, err := pSchedule.UpdateMatch("2023-09-10-d-a", func(m *match.Match) error {
+				return m.SetScore("2-1")
+			})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(8))
+
+			losses, err := pSchedule.GetLossesForTeam("A", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(losses).To(Equal(1))
+		})
+	})
+
+	Describe("Project", func() {
+		It("should give each fixture to the favorite by current RPI", func() {
+			// Arrange
+			current, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(current.Find("B").RPI).To(BeNumerically("<", current.Find("C").RPI))
+
+			// Act
+			projection, err := pSchedule.Project(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projection.Predictions).To(HaveLen(3))
+
+			var results []string
+			for _, prediction := range projection.Predictions {
+				Expect(prediction.Supplied).To(BeFalse())
+				Expect(prediction.Match.IsPlayed()).To(BeTrue())
+				results = append(results, prediction.Match.ToString())
+			}
+
+			Expect(results).To(Equal([]string{"D,0,A,1", "B,0,C,1", "E,0,A,1"}))
+		})
+
+		It("should favor a rated team over one that isn't rated yet", func() {
+			// Arrange
+			Expect(pSchedule.AddMatchFromString("2023-09-20,F,,B,")).To(Succeed())
+
+			// Act
+			projection, err := pSchedule.Project(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projection.Predictions[3].Match.ToString()).To(Equal("F,0,B,1"))
+
+			f := projection.Ratings.Find("F")
+			Expect(f.Remaining).To(Equal(1))
+			Expect(math.IsNaN(f.CurrentRPI)).To(BeTrue())
+			Expect(math.IsNaN(f.RPIChange())).To(BeTrue())
+		})
+
+		It("should leave the schedule's fixtures unchanged", func() {
+			// Act
+			_, err := pSchedule.Project(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			m, err := pSchedule.GetMatch("2023-09-10-d-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.State).To(Equal(match.StateScheduled))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(7))
+		})
+
+		It("should rate the teams over the completed season", func() {
+			// Arrange
+			season := schedule.NewSchedule()
+			for _, line := range []string{
+				"2023-09-01,A,2,B,0",
+				"2023-09-02,C,1,D,0",
+				"2023-09-03,A,1,C,0",
+				"2023-09-04,B,1,D,1",
+				"2023-09-05,D,2,E,0",
+				"2023-09-06,E,1,B,0",
+				"2023-09-07,C,2,E,2",
+				"2023-09-10,D,0,A,1",
+				"2023-09-11,B,0,C,1",
+				"2023-09-12,E,0,A,1",
+			} {
+				Expect(season.AddMatchFromString(line)).To(Succeed())
+			}
+
+			expected, err := season.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			current, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			projection, err := pSchedule.Project(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projection.Ratings).To(HaveLen(5))
+
+			for _, rating := range expected {
+				projected := projection.Ratings.Find(rating.Team)
+				Expect(projected).NotTo(BeNil())
+				Expect(projected.Rating).To(Equal(rating))
+			}
+
+			a := projection.Ratings.Find("A")
+			Expect(a.Remaining).To(Equal(2))
+			Expect(a.CurrentRPI).To(Equal(current.Find("A").RPI))
+			Expect(a.RPIChange()).To(Equal(a.RPI - current.Find("A").RPI))
+			Expect(projection.Ratings.Find("B").Remaining).To(Equal(1))
+
+			projection.Ratings.SortByRPI()
+			Expect(projection.Ratings[0].Team).To(Equal("A"))
+		})
+
+		It("should use the supplied outcomes", func() {
+			// Act
+			projection, err := pSchedule.Project(schedule.Outcomes{"2023-09-10-d-a": "3-1", "2023-09-11-b-c": "1-1 (4-3 PK)"})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projection.Predictions[0].Supplied).To(BeTrue())
+			Expect(projection.Predictions[0].Match.ToString()).To(Equal("D,3,A,1"))
+			Expect(projection.Predictions[1].Match.Decision).To(Equal(match.DecisionShootout))
+			Expect(projection.Predictions[2].Supplied).To(BeFalse())
+			Expect(projection.Ratings.Find("A").Losses).To(Equal(1))
+		})
+
+		It("should leave out postponed fixtures only when they are cancelled", func() {
+			// Arrange
+			_, err := pSchedule.UpdateMatch("2023-09-10-d-a", func(m *match.Match) error {
+				m.State = match.StatePostponed
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = pSchedule.UpdateMatch("2023-09-12-e-a", func(m *match.Match) error {
+				m.State = match.StateCancelled
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			projection, err := pSchedule.Project(nil)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projection.Predictions).To(HaveLen(2))
+			Expect(projection.Predictions[0].Match.ID).To(Equal("2023-09-10-d-a"))
+			Expect(projection.Ratings.Find("A").Remaining).To(Equal(1))
+			Expect(projection.Ratings.Find("E").Remaining).To(Equal(0))
+		})
+
+		It("should reject an outcome for a match that isn't a fixture", func() {
+			// Act
+			_, err := pSchedule.Project(schedule.Outcomes{"2023-09-01-a-b": "0-2"})
+
+			// Assert
+			Expect(err).To(MatchError("match 2023-09-01-a-b isn't a fixture to be played"))
+		})
+
+		It("should reject an outcome for an unknown match", func() {
+			// Act
+			_, err := pSchedule.Project(schedule.Outcomes{"2023-09-30-a-b": "0-2"})
+
+			// Assert
+			Expect(err).To(MatchError("no match with id 2023-09-30-a-b"))
+		})
+	})
+
+	Describe("ParseOutcomes", func() {
+		It("should read one outcome per line", func() {
+			// Arrange
+			input := "# week 2\n2023-09-10-d-a 3-1\n\n2023-09-11-b-c 1-1 (4-3 PK)\n"
+
+			// Act
+			outcomes, err := schedule.ParseOutcomes(strings.NewReader(input))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outcomes).To(Equal(schedule.Outcomes{"2023-09-10-d-a": "3-1", "2023-09-11-b-c": "1-1 (4-3 PK)"}))
+		})
+
+		DescribeTable("should report an invalid line",
+			func(input, message string) {
+				// Act
+				_, err := schedule.ParseOutcomes(strings.NewReader(input))
+
+				// Assert
+				Expect(err).To(MatchError(message))
+			},
+			Entry("without a score", "2023-09-10-d-a\n", "line 1: 2023-09-10-d-a requires a score"),
+			Entry("with a bad score", "2023-09-10-d-a 3\n", "line 1: invalid score <3>"),
+			Entry("with a second outcome", "2023-09-10-d-a 3-1\n2023-09-10-d-a 1-3\n", "line 2: a second outcome for 2023-09-10-d-a"),
+		)
+	})
+})
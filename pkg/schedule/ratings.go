@@ -0,0 +1,345 @@
+package schedule
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Rating holds the RPI elements computed for a single team.
+type Rating struct {
+	Team   string
+	Wins   int
+	Losses int
+	Ties   int
+	WP     float64
+	OWP    float64
+	OOWP   float64
+	RPI    float64
+}
+
+// Ratings is the collection of ratings for every team in a schedule.
+type Ratings []*Rating
+
+// Find returns the rating for the specified team or nil if the team has no rating.
+func (r Ratings) Find(teamName string) *Rating {
+	for _, rating := range r {
+		if rating.Team == teamName {
+			return rating
+		}
+	}
+
+	return nil
+}
+
+// SortByRPI orders the ratings from the highest RPI to the lowest.  Equal ratings are
+// ordered by team name and ratings with an undefined RPI are placed last.
+func (r Ratings) SortByRPI() {
+	sortByValue(r, func(rating *Rating) (string, float64) {
+		return rating.Team, rating.RPI
+	})
+}
+
+// sortByValue orders items from the highest value to the lowest.  Equal values are ordered by
+// team name and undefined values are placed last.
+func sortByValue[T any](items []T, key func(T) (string, float64)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		aTeam, a := key(items[i])
+		bTeam, b := key(items[j])
+
+		if math.IsNaN(a) || math.IsNaN(b) {
+			if math.IsNaN(a) != math.IsNaN(b) {
+				return !math.IsNaN(a)
+			}
+			return aTeam < bTeam
+		}
+
+		if a != b {
+			return a > b
+		}
+
+		return aTeam < bTeam
+	})
+}
+
+// tally aggregates a set of matches once so that every element of the RPI can be
+// computed for every team without rescanning the matches.
+type tally struct {
+	formula   Formula
+	exact     exactFormula
+	teams     []string
+	records   map[string]split
+	opponents map[string][]string
+	versus    map[string]map[string]split
+}
+
+func newTally(matches []*Match, formula Formula) *tally {
+	t := &tally{
+		formula:   formula,
+		exact:     newExactFormula(formula),
+		records:   make(map[string]split),
+		opponents: make(map[string][]string),
+		versus:    make(map[string]map[string]split),
+	}
+
+	for _, m := range matches {
+		if m.ResultUnder(m.Home.Name, formula.Decisions) == ResultNone {
+			continue
+		}
+
+		t.add(m.Home.Name, m.Away.Name, m)
+		t.add(m.Away.Name, m.Home.Name, m)
+	}
+
+	return t
+}
+
+func (t *tally) add(teamName, opponentName string, m *Match) {
+	if _, ok := t.records[teamName]; !ok {
+		t.teams = append(t.teams, teamName)
+		t.versus[teamName] = make(map[string]split)
+	}
+
+	if _, ok := t.versus[teamName][opponentName]; !ok {
+		t.opponents[teamName] = append(t.opponents[teamName], opponentName)
+	}
+
+	r := t.result(teamName, m)
+	t.records[teamName] = t.records[teamName].plus(r)
+	t.versus[teamName][opponentName] = t.versus[teamName][opponentName].plus(r)
+}
+
+// result returns the team's record in a single match.
+func (t *tally) result(teamName string, m *Match) split {
+	var r split
+	location := m.LocationOf(teamName)
+	switch m.ResultUnder(teamName, t.formula.Decisions) {
+	case ResultWin:
+		r[location].Wins++
+	case ResultLoss:
+		r[location].Losses++
+	case ResultTie:
+		r[location].Ties++
+	}
+
+	return r
+}
+
+// meetings returns the number of matches played between two teams.
+func (t *tally) meetings(teamA, teamB string) int {
+	return t.versus[teamA][teamB].total().Total()
+}
+
+// percentage returns the winning percentage for a record using the formula's result values, or nil
+// when the record is empty.
+func (t *tally) percentage(r Record) *big.Rat {
+	return t.weightedPercentage(split{LocationNeutral: r}, false)
+}
+
+// weightedPercentage returns the winning percentage for a record, or nil when the record is empty.
+// When weighted is true each result counts as the number of games given by the formula's weighting
+// for the location it was played at.
+func (t *tally) weightedPercentage(s split, weighted bool) *big.Rat {
+	credit, games := t.weightedCredit(s, weighted)
+	if games.Sign() == 0 {
+		return nil
+	}
+
+	return credit.Quo(credit, games)
+}
+
+// weightedCredit returns the credit for a record using the formula's result values and the number
+// of games it counts as.  When weighted is true each result counts as the number of games given by
+// the formula's weighting for the location it was played at.
+func (t *tally) weightedCredit(s split, weighted bool) (*big.Rat, *big.Rat) {
+	credit := new(big.Rat)
+	games := new(big.Rat)
+
+	for i, r := range s {
+		wins := new(big.Rat).SetInt64(int64(r.Wins))
+		losses := new(big.Rat).SetInt64(int64(r.Losses))
+		ties := new(big.Rat).SetInt64(int64(r.Ties))
+
+		if weighted {
+			weights := t.exact.weighting[i]
+			wins.Mul(wins, weights[ResultWin])
+			losses.Mul(losses, weights[ResultLoss])
+			ties.Mul(ties, weights[ResultTie])
+		}
+
+		credit.Add(credit, new(big.Rat).Mul(wins, t.exact.values[ResultWin]))
+		credit.Add(credit, new(big.Rat).Mul(ties, t.exact.values[ResultTie]))
+		credit.Add(credit, new(big.Rat).Mul(losses, t.exact.values[ResultLoss]))
+		games.Add(games, wins).Add(games, losses).Add(games, ties)
+	}
+
+	return credit, games
+}
+
+// wp returns the team's winning percentage excluding any matches against skipTeamName.  The
+// percentage is weighted by location when the formula calls for it.
+func (t *tally) wp(teamName, skipTeamName string) *big.Rat {
+	s := t.records[teamName]
+	if skipTeamName != "" {
+		s = s.minus(t.versus[teamName][skipTeamName])
+	}
+
+	if t.formula.WeightedWP {
+		return t.weightedPercentage(s, true)
+	}
+
+	return t.percentage(s.total())
+}
+
+// opponentWP returns the winning percentage of an opponent, excluding its matches against teamName
+// when the formula calls for it.
+func (t *tally) opponentWP(opponentName, teamName string) *big.Rat {
+	s := t.records[opponentName]
+	if t.formula.OWPExcludesTeam {
+		s = s.minus(t.versus[opponentName][teamName])
+	}
+
+	return t.percentage(s.total())
+}
+
+// weightedAverage averages a per-opponent value, weighting each opponent by the number of meetings.
+// For example, if Team A has played Team B 3 times and Team C 2 times, then the average is:
+//
+//	((Team B's value * 3) + (Team C's value * 2)) / 5
+//
+// The average is nil when the team has no opponents or any opponent's value is nil.
+func (t *tally) weightedAverage(teamName string, value func(opponentName string) *big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	numberOfMatches := 0
+
+	for _, opponentName := range t.opponents[teamName] {
+		opponentValue := value(opponentName)
+		if opponentValue == nil {
+			return nil
+		}
+
+		meetingCount := t.meetings(teamName, opponentName)
+		numberOfMatches += meetingCount
+		sum.Add(sum, new(big.Rat).Mul(opponentValue, new(big.Rat).SetInt64(int64(meetingCount))))
+	}
+
+	if numberOfMatches == 0 {
+		return nil
+	}
+
+	return sum.Quo(sum, new(big.Rat).SetInt64(int64(numberOfMatches)))
+}
+
+func (t *tally) owp(teamName string) *big.Rat {
+	return t.weightedAverage(teamName, func(opponentName string) *big.Rat {
+		return t.opponentWP(opponentName, teamName)
+	})
+}
+
+func (t *tally) oowp(teamName string) *big.Rat {
+	return t.weightedAverage(teamName, t.owp)
+}
+
+func (t *tally) rpi(teamName string) *big.Rat {
+	return t.combine(t.wp(teamName, ""), t.owp(teamName), t.oowp(teamName))
+}
+
+// combine weights the three elements into a rating, which is nil when any element is nil.
+func (t *tally) combine(wp, owp, oowp *big.Rat) *big.Rat {
+	if wp == nil || owp == nil || oowp == nil {
+		return nil
+	}
+
+	rpi := new(big.Rat).Mul(wp, t.exact.elements[0])
+	rpi.Add(rpi, new(big.Rat).Mul(owp, t.exact.elements[1]))
+	rpi.Add(rpi, new(big.Rat).Mul(oowp, t.exact.elements[2]))
+
+	return rpi
+}
+
+// rating returns the exact rating of a team, using owps to look up the opponents' winning
+// percentages that have already been calculated.
+func (t *tally) rating(teamName string, owps map[string]*big.Rat) *ExactRating {
+	r := t.records[teamName].total()
+
+	wp := t.wp(teamName, "")
+	owp := owps[teamName]
+	oowp := t.weightedAverage(teamName, func(opponentName string) *big.Rat {
+		return owps[opponentName]
+	})
+
+	return &ExactRating{
+		Team:   teamName,
+		Wins:   r.Wins,
+		Losses: r.Losses,
+		Ties:   r.Ties,
+		WP:     NewElement(wp),
+		OWP:    NewElement(owp),
+		OOWP:   NewElement(oowp),
+		RPI:    NewElement(t.combine(wp, owp, oowp)),
+	}
+}
+
+// owps calculates the opponents' winning percentage of the specified teams once so that it can be
+// shared by every team's OOWP.
+func (t *tally) owps(teamNames []string) map[string]*big.Rat {
+	owps := make(map[string]*big.Rat, len(teamNames))
+	for _, teamName := range teamNames {
+		owps[teamName] = t.owp(teamName)
+	}
+
+	return owps
+}
+
+// CalculateAllExact calculates the exact WP, OWP, OOWP and RPI of every eligible team in the schedule
+// in a single pass over the rated matches.
+func (s *Schedule) CalculateAllExact() (ExactRatings, error) {
+	return s.snapshot().allExact(), nil
+}
+
+// allExact calculates the exact ratings of every eligible team in the snapshot.
+func (v *snapshot) allExact() ExactRatings {
+	t := v.tally()
+	owps := t.owps(t.teams)
+
+	ratings := make(ExactRatings, 0, len(t.teams))
+	for _, teamName := range t.teams {
+		ratings = append(ratings, t.rating(teamName, owps))
+	}
+
+	return ratings
+}
+
+// CalculateExact calculates the exact WP, OWP, OOWP and RPI of the specified team.
+func (s *Schedule) CalculateExact(teamName string) (*ExactRating, error) {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
+		return nil, err
+	}
+
+	t := v.tally()
+	owps := t.owps(append([]string{teamName}, t.opponents[teamName]...))
+
+	return t.rating(teamName, owps), nil
+}
+
+// CalculateAll calculates the WP, OWP, OOWP and RPI of every eligible team in the schedule in a single
+// pass over the rated matches.  The elements are calculated exactly and converted to the nearest
+// float64, so the results are identical to calling the per-team methods for each team.
+func (s *Schedule) CalculateAll() (Ratings, error) {
+	return s.snapshot().all(), nil
+}
+
+// all calculates the ratings of every eligible team in the snapshot.
+func (v *snapshot) all() Ratings {
+	exactRatings := v.allExact()
+
+	ratings := make(Ratings, 0, len(exactRatings))
+	for _, rating := range exactRatings {
+		ratings = append(ratings, rating.Rating())
+	}
+
+	return ratings
+}
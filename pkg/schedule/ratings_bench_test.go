@@ -0,0 +1,63 @@
+package schedule_test
+
+import "testing"
+
+// A Division I women's soccer season has roughly 330 teams playing roughly 6,000 matches.
+const (
+	benchmarkTeams   = 330
+	benchmarkMatches = 6000
+)
+
+// BenchmarkCalculateAll ranks every team of a Division I sized season in a single pass.
+func BenchmarkCalculateAll(b *testing.B) {
+	s := generateSeason(1, benchmarkTeams, benchmarkMatches)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.CalculateAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCalculateRPI calculates the RPI of a single team of a Division I sized season with the
+// per-team method.  Ranking the whole season this way costs roughly benchmarkTeams times as much.
+func BenchmarkCalculateRPI(b *testing.B) {
+	s := generateSeason(1, benchmarkTeams, benchmarkMatches)
+	teamName := s.GetTeams()[0]
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.CalculateRPI(teamName); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCalculateRPIEveryTeam ranks every team of a smaller season with the per-team methods
+// so that it can be compared against BenchmarkCalculateAllSmall.
+func BenchmarkCalculateRPIEveryTeam(b *testing.B) {
+	s := generateSeason(1, 60, 600)
+	teams := s.GetTeams()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, teamName := range teams {
+			if _, err := s.CalculateRPI(teamName); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkCalculateAllSmall ranks every team of the season used by BenchmarkCalculateRPIEveryTeam.
+func BenchmarkCalculateAllSmall(b *testing.B) {
+	s := generateSeason(1, 60, 600)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := s.CalculateAll(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
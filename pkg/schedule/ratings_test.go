@@ -0,0 +1,170 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ratings", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("UConn,64,Kansas,57")
+		pSchedule.AddMatchFromString("UConn,82,Duke,68")
+		pSchedule.AddMatchFromString("Wisconsin,71,UConn,72")
+		pSchedule.AddMatchFromString("Kansas,69,UConn,62")
+		pSchedule.AddMatchFromString("Duke,81,Wisconsin,70")
+		pSchedule.AddMatchFromString("Wisconsin,52,Kansas,62")
+	})
+
+	AfterEach(func() {
+		pSchedule = nil
+	})
+
+	expectSameAsPerTeam := func(s *schedule.Schedule, ratings schedule.Ratings) {
+		Expect(ratings).To(HaveLen(len(s.GetTeams())))
+
+		for _, rating := range ratings {
+			wins, err := s.GetWinsForTeam(rating.Team, "")
+			Expect(err).NotTo(HaveOccurred())
+			losses, err := s.GetLossesForTeam(rating.Team, "")
+			Expect(err).NotTo(HaveOccurred())
+			ties, err := s.GetTiesForTeam(rating.Team, "")
+			Expect(err).NotTo(HaveOccurred())
+			wp, err := s.CalculateWP(rating.Team, "")
+			Expect(err).NotTo(HaveOccurred())
+			owp, err := s.CalculateOWP(rating.Team)
+			Expect(err).NotTo(HaveOccurred())
+			oowp, err := s.CalculateOOWP(rating.Team)
+			Expect(err).NotTo(HaveOccurred())
+			rpi, err := s.CalculateRPI(rating.Team)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rating.Wins).To(Equal(wins), rating.Team)
+			Expect(rating.Losses).To(Equal(losses), rating.Team)
+			Expect(rating.Ties).To(Equal(ties), rating.Team)
+			Expect(rating.WP).To(BeNumerically("~", wp, 1e-12), rating.Team)
+			Expect(rating.OWP).To(BeNumerically("~", owp, 1e-12), rating.Team)
+			Expect(rating.OOWP).To(BeNumerically("~", oowp, 1e-12), rating.Team)
+			Expect(rating.RPI).To(BeNumerically("~", rpi, 1e-12), rating.Team)
+		}
+	}
+
+	Describe("CalculateAll", func() {
+		It("should return a rating for every team in the order they first appear", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(HaveLen(4))
+			Expect(ratings[0].Team).To(Equal("UConn"))
+			Expect(ratings[1].Team).To(Equal("Kansas"))
+			Expect(ratings[2].Team).To(Equal("Duke"))
+			Expect(ratings[3].Team).To(Equal("Wisconsin"))
+		})
+
+		It("should calculate the correct elements for UConn", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			rating := ratings.Find("UConn")
+			Expect(rating).NotTo(BeNil())
+			Expect(rating.Wins).To(Equal(3))
+			Expect(rating.Losses).To(Equal(1))
+			Expect(rating.Ties).To(Equal(0))
+			Expect(rating.WP).To(BeNumerically("~", 0.7500, 0.0001))
+			Expect(rating.OWP).To(BeNumerically("~", 0.7500, 0.0001))
+			Expect(rating.OOWP).To(BeNumerically("~", 0.5139, 0.0001))
+			Expect(rating.RPI).To(BeNumerically("~", 0.6910, 0.0001))
+		})
+
+		It("should match the per-team calculations", func() {
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			expectSameAsPerTeam(pSchedule, ratings)
+		})
+
+		It("should match the per-team calculations for a season with draws and repeat meetings", func() {
+			// Arrange
+			season := generateSeason(42, 24, 240)
+
+			// Act
+			ratings, err := season.CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			expectSameAsPerTeam(season, ratings)
+		})
+
+		It("should return no ratings for an empty schedule", func() {
+			// Act
+			ratings, err := schedule.NewSchedule().CalculateAll()
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ratings).To(BeEmpty())
+		})
+	})
+
+	Describe("Find", func() {
+		It("should return nil for a team that doesn't exist", func() {
+			// Arrange
+			ratings, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			rating := ratings.Find("Foo")
+
+			// Assert
+			Expect(rating).To(BeNil())
+		})
+	})
+
+	Describe("SortByRPI", func() {
+		It("should order the ratings from the highest RPI to the lowest", func() {
+			// Arrange
+			ratings, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			ratings.SortByRPI()
+
+			// Assert
+			Expect(ratings[0].Team).To(Equal("UConn"))
+			Expect(ratings[1].Team).To(Equal("Kansas"))
+			Expect(ratings[2].Team).To(Equal("Duke"))
+			Expect(ratings[3].Team).To(Equal("Wisconsin"))
+		})
+
+		It("should order equal ratings by name and undefined ratings last", func() {
+			// Arrange
+			ratings := schedule.Ratings{
+				{Team: "C", RPI: math.NaN()},
+				{Team: "B", RPI: 0.5},
+				{Team: "A", RPI: 0.5},
+				{Team: "D", RPI: 0.6},
+			}
+
+			// Act
+			ratings.SortByRPI()
+
+			// Assert
+			Expect(ratings[0].Team).To(Equal("D"))
+			Expect(ratings[1].Team).To(Equal("A"))
+			Expect(ratings[2].Team).To(Equal("B"))
+			Expect(ratings[3].Team).To(Equal("C"))
+		})
+	})
+})
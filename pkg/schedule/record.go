@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"fmt"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Record is a team's wins, losses and ties over some set of matches.
+type Record struct {
+	Wins   int `json:"wins" yaml:"wins"`
+	Losses int `json:"losses" yaml:"losses"`
+	Ties   int `json:"ties" yaml:"ties"`
+}
+
+// Total returns the number of matches in the record.
+func (r Record) Total() int {
+	return r.Wins + r.Losses + r.Ties
+}
+
+// ToString returns the record in the form W-L-T.
+func (r Record) ToString() string {
+	return fmt.Sprintf("%d-%d-%d", r.Wins, r.Losses, r.Ties)
+}
+
+func (r Record) plus(other Record) Record {
+	return Record{
+		Wins:   r.Wins + other.Wins,
+		Losses: r.Losses + other.Losses,
+		Ties:   r.Ties + other.Ties,
+	}
+}
+
+func (r Record) minus(other Record) Record {
+	return Record{
+		Wins:   r.Wins - other.Wins,
+		Losses: r.Losses - other.Losses,
+		Ties:   r.Ties - other.Ties,
+	}
+}
+
+// Splits is a team's record at home, on the road and at neutral sites.
+type Splits struct {
+	Home    Record `json:"home" yaml:"home"`
+	Away    Record `json:"away" yaml:"away"`
+	Neutral Record `json:"neutral" yaml:"neutral"`
+}
+
+// Overall returns the team's combined record.
+func (s Splits) Overall() Record {
+	return s.Home.plus(s.Away).plus(s.Neutral)
+}
+
+// split is a team's record at each location, indexed by Location.
+type split [LocationNeutral + 1]Record
+
+func (s split) total() Record {
+	var r Record
+	for _, locationRecord := range s {
+		r = r.plus(locationRecord)
+	}
+
+	return r
+}
+
+func (s split) plus(other split) split {
+	for location := range s {
+		s[location] = s[location].plus(other[location])
+	}
+
+	return s
+}
+
+func (s split) minus(other split) split {
+	for location := range s {
+		s[location] = s[location].minus(other[location])
+	}
+
+	return s
+}
+
+func (s split) splits() Splits {
+	return Splits{
+		Home:    s[LocationHome],
+		Away:    s[LocationAway],
+		Neutral: s[LocationNeutral],
+	}
+}
+
+// GetRecord returns the team's overall wins, losses and ties.
+func (s *Schedule) GetRecord(teamName string) (Record, error) {
+	splits, err := s.GetSplits(teamName)
+	if err != nil {
+		return Record{}, err
+	}
+
+	return splits.Overall(), nil
+}
+
+// GetSplits returns the team's record at home, on the road and at neutral sites.
+func (s *Schedule) GetSplits(teamName string) (Splits, error) {
+	v := s.snapshot()
+	if err := v.checkTeam(teamName); err != nil {
+		return Splits{}, err
+	}
+
+	return newTally(v.matches, v.formula).records[teamName].splits(), nil
+}
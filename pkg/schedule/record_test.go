@@ -0,0 +1,86 @@
+package schedule_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Record", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		pSchedule.AddMatchFromString("2023-09-01,Duke,2,UNC,1")
+		pSchedule.AddMatchFromString("2023-09-05,UNC,1,Duke,1")
+		pSchedule.AddMatchFromString("2023-09-09,Duke,0,Stanford,3,N,WakeMed Soccer Park,Cary")
+		pSchedule.AddMatchFromString("2023-09-13,Virginia,0,Duke,1")
+		pSchedule.AddMatchFromString("2023-09-17,Duke,4,Wake Forest,0")
+	})
+
+	AfterEach(func() {
+		pSchedule = nil
+	})
+
+	Describe("ToString", func() {
+		It("should return the record in the form W-L-T", func() {
+			Expect(schedule.Record{Wins: 8, Losses: 8, Ties: 4}.ToString()).To(Equal("8-8-4"))
+		})
+	})
+
+	Describe("GetSplits", func() {
+		It("should return the record at each location", func() {
+			// Act
+			splits, err := pSchedule.GetSplits("Duke")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(splits.Home).To(Equal(schedule.Record{Wins: 2, Losses: 0, Ties: 0}))
+			Expect(splits.Away).To(Equal(schedule.Record{Wins: 1, Losses: 0, Ties: 1}))
+			Expect(splits.Neutral).To(Equal(schedule.Record{Wins: 0, Losses: 1, Ties: 0}))
+			Expect(splits.Overall()).To(Equal(schedule.Record{Wins: 3, Losses: 1, Ties: 1}))
+		})
+
+		It("should treat a neutral site as neutral for both teams", func() {
+			// Act
+			splits, err := pSchedule.GetSplits("Stanford")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(splits.Neutral).To(Equal(schedule.Record{Wins: 1}))
+			Expect(splits.Home.Total()).To(Equal(0))
+			Expect(splits.Away.Total()).To(Equal(0))
+		})
+
+		It("should return an error for a team that doesn't exist", func() {
+			// Act
+			_, err := pSchedule.GetSplits("Foo")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no matches found for team Foo"))
+		})
+	})
+
+	Describe("GetRecord", func() {
+		It("should return the overall record", func() {
+			// Act
+			r, err := pSchedule.GetRecord("UNC")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r).To(Equal(schedule.Record{Wins: 0, Losses: 1, Ties: 1}))
+			Expect(r.Total()).To(Equal(2))
+		})
+
+		It("should return an error for an empty team name", func() {
+			// Act
+			_, err := pSchedule.GetRecord("")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the specified team name is empty"))
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Scenario is a set of hypothetical results evaluated against a schedule without changing it.
+type Scenario struct {
+	Name string
+
+	// Results overrides the results of matches in the schedule, played or not, keyed by match ID.
+	Results Outcomes
+
+	// Matches are hypothetical matches added to the schedule.  Each must have a result.
+	Matches []*Match
+}
+
+// Impact is how a scenario changes a team's rating and rank.  The standing is the team's place after
+// the scenario, with its rank and RPI before it as the previous rank and RPI.
+type Impact struct {
+	Standing
+
+	// Before is the team's rating before the scenario, or nil when it wasn't rated.
+	Before *Rating
+}
+
+// Impacts is the impact of a scenario on every team it affects.
+type Impacts []*Impact
+
+// Find returns the impact on the specified team or nil if the scenario doesn't affect it.
+func (i Impacts) Find(teamName string) *Impact {
+	for _, impact := range i {
+		if impact.Team == teamName {
+			return impact
+		}
+	}
+
+	return nil
+}
+
+// ScenarioResult is the outcome of evaluating a scenario.
+type ScenarioResult struct {
+	Name string
+
+	// Impacts lists every team whose rating or rank the scenario changes, ordered by the rank after it.
+	Impacts Impacts
+}
+
+// Evaluate rates the schedule as it would be with the scenario's results and reports each team whose
+// rating or rank changes.  The schedule is unchanged.  Fixtures without a result in the scenario are
+// still left out, and teams the scenario leaves unrated aren't reported.
+func (s *Schedule) Evaluate(scenario *Scenario) (*ScenarioResult, error) {
+	v := s.snapshot()
+
+	after, err := v.apply(scenario)
+	if err != nil {
+		return nil, err
+	}
+
+	before := v.all()
+	before.SortByRPI()
+
+	ratings := after.all()
+	ratings.SortByRPI()
+
+	previous := make(map[string]int, len(before))
+	for i, rating := range before {
+		previous[rating.Team] = i
+	}
+
+	result := &ScenarioResult{Name: scenario.Name}
+	for rank, rating := range ratings {
+		impact := &Impact{Standing: Standing{Rating: rating, Rank: rank + 1, PreviousRPI: math.NaN()}}
+
+		if i, ok := previous[rating.Team]; ok {
+			impact.Before = before[i]
+			impact.PreviousRank = i + 1
+			impact.PreviousRPI = before[i].RPI
+
+			if impact.PreviousRank == impact.Rank && sameRating(impact.Before, rating) {
+				continue
+			}
+		}
+
+		result.Impacts = append(result.Impacts, impact)
+	}
+
+	return result, nil
+}
+
+// apply returns a snapshot of the matches with the scenario's results in place of the schedule's and
+// its hypothetical matches added.  The schedule's matches are copied before they are changed.
+func (v *snapshot) apply(scenario *Scenario) (*snapshot, error) {
+	after := &snapshot{matches: slices.Clone(v.matches), formula: v.formula, registry: v.registry}
+
+	found := make(map[string]bool, len(scenario.Results))
+	for i, match := range after.matches {
+		score, ok := scenario.Results[match.ID]
+		if !ok {
+			continue
+		}
+
+		changed := match.Clone()
+		if err := changed.SetScore(score); err != nil {
+			return nil, fmt.Errorf("match %s: %w", match.ID, err)
+		}
+
+		after.matches[i] = changed
+		found[match.ID] = true
+	}
+
+	for id := range scenario.Results {
+		if !found[id] {
+			return nil, fmt.Errorf("no match with id %s", id)
+		}
+	}
+
+	for _, match := range scenario.Matches {
+		if match == nil {
+			return nil, fmt.Errorf("the specified match is nil")
+		}
+
+		if !match.IsPlayed() {
+			return nil, fmt.Errorf("the hypothetical match between %s and %s has no result", match.Home.Name, match.Away.Name)
+		}
+
+		after.matches = append(after.matches, match)
+	}
+
+	return after, nil
+}
+
+// sameRating reports whether two ratings have the same record and elements, treating undefined
+// elements as equal.
+func sameRating(a, b *Rating) bool {
+	same := func(x, y float64) bool {
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	}
+
+	return a.Wins == b.Wins && a.Losses == b.Losses && a.Ties == b.Ties &&
+		same(a.WP, b.WP) && same(a.OWP, b.OWP) && same(a.OOWP, b.OOWP) && same(a.RPI, b.RPI)
+}
@@ -0,0 +1,146 @@
+package schedule_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scenario", func() {
+	var sSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		sSchedule = schedule.NewSchedule()
+
+		for _, line := range []string{
+			"2023-09-01,A,2,B,0",
+			"2023-09-02,C,1,D,0",
+			"2023-09-03,A,1,C,0",
+			"2023-09-04,B,1,D,1",
+			"2023-09-05,D,2,E,0",
+			"2023-09-06,E,1,B,0",
+			"2023-09-07,C,2,E,2",
+			"2023-09-10,D,,A,",
+			"2023-09-11,B,,C,",
+			"2023-09-12,E,,A,",
+		} {
+			Expect(sSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+	})
+
+	Describe("Evaluate", func() {
+		It("should rate the schedule as it would be with the hypothetical results", func() {
+			// Arrange
+			expected := schedule.NewSchedule()
+			for _, m := range sSchedule.GetMatches() {
+				Expect(expected.AddMatch(m.Clone())).To(Succeed())
+			}
+			_, err := expected.UpdateMatch("2023-09-01-a-b", func(m *match.Match) error { return m.SetScore("0-1") })
+			Expect(err).NotTo(HaveOccurred())
+			_, err = expected.UpdateMatch("2023-09-10-d-a", func(m *match.Match) error { return m.SetScore("3-0") })
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := expected.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			before, err := sSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			scenario := &schedule.Scenario{
+				Name:    "A slumps",
+				Results: schedule.Outcomes{"2023-09-01-a-b": "0-1", "2023-09-10-d-a": "3-0"},
+			}
+
+			// Act
+			result, err := sSchedule.Evaluate(scenario)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Name).To(Equal("A slumps"))
+			Expect(result.Impacts).NotTo(BeEmpty())
+
+			impact := result.Impacts.Find("A")
+			Expect(impact).NotTo(BeNil())
+			Expect(fmt.Sprint(*impact.Rating)).To(Equal(fmt.Sprint(*after.Find("A"))))
+			Expect(fmt.Sprint(*impact.Before)).To(Equal(fmt.Sprint(*before.Find("A"))))
+			Expect(impact.PreviousRPI).To(Equal(before.Find("A").RPI))
+			Expect(impact.RPIChange()).To(BeNumerically("<", 0))
+			Expect(impact.Losses).To(Equal(2))
+
+			for i, impact := range result.Impacts[1:] {
+				Expect(impact.Rank).To(BeNumerically(">", result.Impacts[i].Rank))
+			}
+		})
+
+		It("should leave the schedule unchanged", func() {
+			// Arrange
+			before, err := sSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			_, err = sSchedule.Evaluate(&schedule.Scenario{Results: schedule.Outcomes{"2023-09-01-a-b": "0-3"}})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := sSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before))
+			m, err := sSchedule.GetMatch("2023-09-01-a-b")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.Home.Score).To(Equal(2))
+			Expect(sSchedule.GetTotalMatchesPlayed()).To(Equal(7))
+		})
+
+		It("should report nothing when the scenario changes nothing", func() {
+			// Act
+			result, err := sSchedule.Evaluate(&schedule.Scenario{Results: schedule.Outcomes{"2023-09-01-a-b": "2-0"}})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Impacts).To(BeEmpty())
+		})
+
+		It("should add hypothetical matches", func() {
+			// Arrange
+			hypothetical, err := match.NewMatchFromFields(time.Date(2023, 9, 20, 0, 0, 0, 0, time.UTC), "F", "", "A", "", match.Site{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hypothetical.SetScore("1-0")).To(Succeed())
+
+			// Act
+			result, err := sSchedule.Evaluate(&schedule.Scenario{Matches: []*match.Match{hypothetical}})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			impact := result.Impacts.Find("A")
+			Expect(impact).NotTo(BeNil())
+			Expect(impact.Losses).To(Equal(1))
+
+			newcomer := result.Impacts.Find("F")
+			if newcomer != nil {
+				Expect(newcomer.IsNew()).To(BeTrue())
+				Expect(newcomer.Before).To(BeNil())
+			}
+		})
+
+		DescribeTable("should reject invalid scenarios",
+			func(scenario *schedule.Scenario, message string) {
+				// Act
+				result, err := sSchedule.Evaluate(scenario)
+
+				// Assert
+				Expect(result).To(BeNil())
+				Expect(err).To(MatchError(message))
+			},
+			Entry("an unknown match", &schedule.Scenario{Results: schedule.Outcomes{"2023-09-30-a-b": "1-0"}}, "no match with id 2023-09-30-a-b"),
+			Entry("an invalid score", &schedule.Scenario{Results: schedule.Outcomes{"2023-09-01-a-b": "x"}}, "match 2023-09-01-a-b: invalid score <x>"),
+			Entry("a nil match", &schedule.Scenario{Matches: []*match.Match{nil}}, "the specified match is nil"),
+			Entry("a match without a result", &schedule.Scenario{Matches: []*match.Match{{Home: match.Status{Name: "A"}, Away: match.Status{Name: "B"}, State: match.StateScheduled}}}, "the hypothetical match between A and B has no result"),
+		)
+	})
+})
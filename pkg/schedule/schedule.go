@@ -2,54 +2,252 @@ package schedule
 
 import (
 	"fmt"
-	. "github.com/jedi-knights/rpi/pkg/match"
 	"slices"
+	"sync"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/team"
 )
 
+// Calculator calculates the elements of the RPI.
+type Calculator interface {
+	CalculateWP(teamName, skipTeamName string) (float64, error)
+	CalculateOWP(teamName string) (float64, error)
+	CalculateOOWP(teamName string) (float64, error)
+	CalculateRPI(teamName string) (float64, error)
+	CalculateAll() (Ratings, error)
+}
+
+// ISchedule is a set of matches that can be queried and rated.
 type ISchedule interface {
-	AddMatch(match *Match)
+	Calculator
+
+	AddMatch(match *Match) error
+	RemoveMatch(match *Match) error
+	GetMatch(id string) (*Match, error)
+	RemoveMatchByID(id string) (*Match, error)
+	ReplaceMatch(id string, replacement *Match) (*Match, error)
+	UpdateMatch(id string, update func(match *Match) error) (*Match, error)
+	ReplaceAll(matches []*Match) error
 	GetMatches() []*Match
 	GetMatchesForTeam(teamName string) []*Match
+	GetTeams() []string
 	GetOpponents(teamName string) ([]string, error)
-	GetOpponentsForTeam(teamName string) ([]string, error)
 	GetMatchesPlayedBy(teamName string) ([]*Match, error)
 	GetWinsForTeam(teamName, skipTeamName string) (int, error)
 	GetLossesForTeam(teamName, skipTeamName string) (int, error)
 	GetTiesForTeam(teamName, skipTeamName string) (int, error)
 	GetTotalMatchesPlayedForTeam(teamName string) (int, error)
 	GetTotalMatchesPlayed() int
-	CalculateWP(teamName string) (float64, error)
-	CalculateOWP(teamName string) (float64, error)
-	CalculateOOWP(teamName string) (float64, error)
-	CalculateRPI(teamName string) (float64, error)
 }
 
+var _ ISchedule = (*Schedule)(nil)
+
+// Schedule calculates the RPI of the matches in its store.  A schedule is safe for concurrent use:
+// matches may be added and removed while other goroutines calculate ratings, and every query works
+// from a consistent snapshot of the matches taken when it starts.
 type Schedule struct {
-	matches []*Match
+	mu       sync.RWMutex
+	store    Store
+	ids      map[string]*Match
+	formula  Formula
+	registry *team.Registry
 }
 
+// NewSchedule returns an empty schedule that keeps its matches in memory.
 func NewSchedule() *Schedule {
-	return &Schedule{
-		matches: make([]*Match, 0),
+	return NewScheduleWithStore(NewMemoryStore())
+}
+
+// NewScheduleWithStore returns a schedule that rates the matches in the specified store.  Matches
+// already in the store are given an ID when they don't have a unique one.
+func NewScheduleWithStore(store Store) *Schedule {
+	s := &Schedule{
+		store:   store,
+		formula: DefaultFormula,
+	}
+
+	s.index()
+
+	return s
+}
+
+// GetFormula returns the formula used to calculate the RPI.
+func (s *Schedule) GetFormula() Formula {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.formula
+}
+
+// SetFormula changes the formula used to calculate the RPI.
+func (s *Schedule) SetFormula(formula Formula) error {
+	if err := formula.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.formula = formula
+
+	return nil
+}
+
+// GetRegistry returns the registry used to decide which teams are eligible for the RPI, or nil when
+// every team is eligible.
+func (s *Schedule) GetRegistry() *team.Registry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.registry
+}
+
+// SetRegistry limits the RPI calculations to matches between teams the registry marks as eligible.
+// Records are still kept for every match.  A nil registry makes every team eligible.  The registry
+// must not be changed once it is in use.
+func (s *Schedule) SetRegistry(registry *team.Registry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.registry = registry
+}
+
+// IsEligible reports whether the team's matches against other eligible teams count toward the RPI.
+func (s *Schedule) IsEligible(teamName string) bool {
+	registry := s.GetRegistry()
+	return registry == nil || registry.IsEligible(teamName)
+}
+
+// GetRatedMatches returns the matches that count toward the RPI, which are the played matches between
+// two eligible teams.
+func (s *Schedule) GetRatedMatches() []*Match {
+	return s.snapshot().ratedMatches()
+}
+
+// AddMatch adds the match to the schedule's store.  A match without an ID is given one built from its
+// date and teams.
+func (s *Schedule) AddMatch(match *Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.add(match)
+}
+
+// add stores the match, giving it an ID when it doesn't have one, while the write lock is held.
+func (s *Schedule) add(match *Match) error {
+	if match == nil {
+		return fmt.Errorf("the specified match is nil")
+	}
+
+	if match.ID == "" {
+		match.ID = s.newMatchID(match)
+	} else if s.ids[match.ID] != nil {
+		return fmt.Errorf("a match with id %s already exists", match.ID)
+	}
+
+	if err := s.store.Add(match); err != nil {
+		return err
+	}
+
+	s.ids[match.ID] = match
+
+	return nil
+}
+
+// AddMatchFromString parses the match and adds it to the schedule's store.
+func (s *Schedule) AddMatchFromString(matchString string) error {
+	match := NewMatchFromString(matchString)
+	if match == nil {
+		return fmt.Errorf("unable to parse match %q", matchString)
 	}
+
+	return s.AddMatch(match)
 }
 
-func (s *Schedule) AddMatch(match *Match) {
-	s.matches = append(s.matches, match)
+// RemoveMatch removes the match from the schedule's store.
+func (s *Schedule) RemoveMatch(match *Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.Remove(match); err != nil {
+		return err
+	}
+
+	if s.ids[match.ID] == match {
+		delete(s.ids, match.ID)
+	}
+
+	return nil
 }
 
-func (s *Schedule) AddMatchFromString(matchString string) {
-	s.AddMatch(NewMatchFromString(matchString))
+// ReplaceAll puts the matches in place of every match in the schedule while holding the write lock, so
+// readers see either the old matches or the new ones.  A match without an ID is given one.  Nothing
+// changes when two matches share an ID, and the old matches are restored when the store fails.
+func (s *Schedule) ReplaceAll(matches []*Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		if match == nil {
+			return fmt.Errorf("the specified match is nil")
+		}
+
+		if match.ID != "" && seen[match.ID] {
+			return fmt.Errorf("a match with id %s already exists", match.ID)
+		}
+
+		seen[match.ID] = true
+	}
+
+	old := slices.Clone(s.store.Matches())
+	ids := s.ids
+
+	// The new matches are added before the old ones are removed so that a store that can't add a match
+	// is rolled back by removing matches alone.
+	s.ids = make(map[string]*Match, len(matches))
+	for i, match := range matches {
+		if err := s.add(match); err != nil {
+			s.rollback(matches[:i], nil, ids)
+			return err
+		}
+	}
+
+	for i, match := range old {
+		if err := s.store.Remove(match); err != nil {
+			s.rollback(matches, old[:i], ids)
+			return err
+		}
+	}
+
+	return nil
 }
 
+// rollback undoes a change that failed partway by removing the matches it added, adding back the
+// matches it removed and restoring the index.  The store's errors are ignored, as there is nothing
+// left to fall back on.
+func (s *Schedule) rollback(added, removed []*Match, ids map[string]*Match) {
+	for _, match := range added {
+		_ = s.store.Remove(match)
+	}
+
+	for _, match := range removed {
+		_ = s.store.Add(match)
+	}
+
+	s.ids = ids
+}
+
+// GetMatches returns a copy of the schedule's matches in the order they were added.
 func (s *Schedule) GetMatches() []*Match {
-	return s.matches
+	return s.snapshot().matches
 }
 
 func (s *Schedule) GetMatchesForTeam(teamName string) []*Match {
 	var matches []*Match
 
-	for _, match := range s.matches {
+	for _, match := range s.GetMatches() {
 		if match.Contains(teamName) {
 			matches = append(matches, match)
 		}
@@ -58,6 +256,23 @@ func (s *Schedule) GetMatchesForTeam(teamName string) []*Match {
 	return matches
 }
 
+// GetTeams returns the name of every team in the schedule in the order they first appear.
+func (s *Schedule) GetTeams() []string {
+	var teams []string
+
+	seen := make(map[string]bool)
+	for _, match := range s.GetMatches() {
+		for _, teamName := range []string{match.Home.Name, match.Away.Name} {
+			if !seen[teamName] {
+				seen[teamName] = true
+				teams = append(teams, teamName)
+			}
+		}
+	}
+
+	return teams
+}
+
 func (s *Schedule) GetOpponents(teamName string) ([]string, error) {
 	var opponents []string
 
@@ -65,7 +280,7 @@ func (s *Schedule) GetOpponents(teamName string) ([]string, error) {
 		return nil, fmt.Errorf("the specified team name is empty")
 	}
 
-	for _, match := range s.matches {
+	for _, match := range s.GetMatches() {
 		var err error
 		var opponentName string
 
@@ -88,7 +303,7 @@ func (s *Schedule) GetMatchesPlayedBy(teamName string) ([]*Match, error) {
 		return nil, fmt.Errorf("the specified team name is empty")
 	}
 
-	for _, match := range s.matches {
+	for _, match := range s.GetMatches() {
 		if match.Contains(teamName) {
 			matchesPlayed = append(matchesPlayed, match)
 		}
@@ -102,92 +317,41 @@ func (s *Schedule) GetMatchesPlayedBy(teamName string) ([]*Match, error) {
 }
 
 func (s *Schedule) Contains(teamName string) bool {
-	for _, match := range s.matches {
-		if match.Contains(teamName) {
-			return true
-		}
-	}
-
-	return false
+	return s.snapshot().contains(teamName)
 }
 
+// GetWinsForTeam returns the number of wins for the team, excluding any matches against skipTeamName
+// when it is not empty.  Matches are credited according to the formula's decision rules.
 func (s *Schedule) GetWinsForTeam(teamName, skipTeamName string) (int, error) {
-	var total int
-
-	if teamName == "" {
-		return 0, fmt.Errorf("the specified team name is empty")
-	}
-
-	if !s.Contains(teamName) {
-		return 0, fmt.Errorf("no matches found for team %s", teamName)
-	}
-
-	for _, match := range s.matches {
-		if !match.Contains(teamName) {
-			continue
-		}
-
-		if len(skipTeamName) > 0 && match.Contains(skipTeamName) {
-			continue
-		}
-
-		if match.IsWinner(teamName) {
-			total++
-		}
-	}
-
-	return total, nil
+	return s.countResults(teamName, skipTeamName, ResultWin)
 }
 
+// GetLossesForTeam returns the number of losses for the team, excluding any matches against skipTeamName
+// when it is not empty.  Matches are credited according to the formula's decision rules.
 func (s *Schedule) GetLossesForTeam(teamName, skipTeamName string) (int, error) {
-	var total int
-
-	if teamName == "" {
-		return 0, fmt.Errorf("the specified team name is empty")
-	}
-
-	if !s.Contains(teamName) {
-		return 0, fmt.Errorf("no matches found for team %s", teamName)
-	}
-
-	for _, match := range s.matches {
-		if !match.Contains(teamName) {
-			continue
-		}
-
-		if len(skipTeamName) > 0 && match.Contains(skipTeamName) {
-			continue
-		}
-
-		if match.IsLoser(teamName) {
-			total++
-		}
-	}
-
-	return total, nil
+	return s.countResults(teamName, skipTeamName, ResultLoss)
 }
 
+// GetTiesForTeam returns the number of ties for the team, excluding any matches against skipTeamName
+// when it is not empty.  Matches are credited according to the formula's decision rules.
 func (s *Schedule) GetTiesForTeam(teamName, skipTeamName string) (int, error) {
-	var total int
+	return s.countResults(teamName, skipTeamName, ResultTie)
+}
 
-	if teamName == "" {
-		return 0, fmt.Errorf("the specified team name is empty")
-	}
+func (s *Schedule) countResults(teamName, skipTeamName string, result Result) (int, error) {
+	var total int
 
-	if !s.Contains(teamName) {
-		return 0, fmt.Errorf("no matches found for team %s", teamName)
+	v := s.snapshot()
+	if err := v.checkTeam(teamName); err != nil {
+		return 0, err
 	}
 
-	for _, match := range s.matches {
-		if !match.Contains(teamName) {
-			continue
-		}
-
+	for _, match := range v.matches {
 		if len(skipTeamName) > 0 && match.Contains(skipTeamName) {
 			continue
 		}
 
-		if match.IsDraw() {
+		if match.ResultUnder(teamName, v.formula.Decisions) == result {
 			total++
 		}
 	}
@@ -195,6 +359,8 @@ func (s *Schedule) GetTiesForTeam(teamName, skipTeamName string) (int, error) {
 	return total, nil
 }
 
+// GetTotalMatchesPlayedForTeam returns the number of matches the team has played, leaving out its
+// fixtures that haven't been played.
 func (s *Schedule) GetTotalMatchesPlayedForTeam(teamName string) (int, error) {
 	var totalMatchesPlayed int
 
@@ -203,9 +369,9 @@ func (s *Schedule) GetTotalMatchesPlayedForTeam(teamName string) (int, error) {
 	}
 
 	found := false
-	for _, match := range s.matches {
+	for _, match := range s.GetMatches() {
 		found = found || match.Contains(teamName)
-		if match.Contains(teamName) {
+		if match.Contains(teamName) && match.IsPlayed() {
 			totalMatchesPlayed++
 		}
 	}
@@ -217,37 +383,20 @@ func (s *Schedule) GetTotalMatchesPlayedForTeam(teamName string) (int, error) {
 	return totalMatchesPlayed, nil
 }
 
+// GetTotalMatchesPlayed returns the number of matches that have been played, leaving out fixtures.
 func (s *Schedule) GetTotalMatchesPlayed() int {
-	return len(s.matches)
+	return len(s.snapshot().played())
 }
 
+// CalculateWP calculates the winning percentage of the specified team, excluding any matches
+// against skipTeamName when it is not empty.
 func (s *Schedule) CalculateWP(teamName, skipTeamName string) (float64, error) {
-	var winningPercentage float64
-
-	if teamName == "" {
-		return 0.0, fmt.Errorf("the specified team name is empty")
-	}
-
-	wins, err := s.GetWinsForTeam(teamName, skipTeamName)
-	if err != nil {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
 		return 0.0, err
 	}
 
-	losses, err := s.GetLossesForTeam(teamName, skipTeamName)
-	if err != nil {
-		return 0.0, err
-	}
-
-	ties, err := s.GetTiesForTeam(teamName, skipTeamName)
-	if err != nil {
-		return 0.0, err
-	}
-
-	totalMatchesPlayed := wins + losses + ties
-
-	winningPercentage = float64(wins+(ties/2)) / float64(totalMatchesPlayed)
-
-	return winningPercentage, nil
+	return NewElement(v.tally().wp(teamName, skipTeamName)).Float(), nil
 }
 
 func (s *Schedule) GetMeetingCount(teamA, teamB string) (int, error) {
@@ -261,7 +410,7 @@ func (s *Schedule) GetMeetingCount(teamA, teamB string) (int, error) {
 		return 0, fmt.Errorf("the second specified team name is empty")
 	}
 
-	for _, currentMatch := range s.matches {
+	for _, currentMatch := range s.GetMatches() {
 		if !currentMatch.Contains(teamA) {
 			continue
 		}
@@ -276,146 +425,34 @@ func (s *Schedule) GetMeetingCount(teamA, teamB string) (int, error) {
 	return meetingCount, nil
 }
 
-func (s *Schedule) CalculateOWP(targetTeamName string) (float64, error) {
-	if targetTeamName == "" {
-		return 0.0, fmt.Errorf("the specified team name is empty")
-	}
-
-	if !s.Contains(targetTeamName) {
-		return 0.0, fmt.Errorf("no matches found for team %s", targetTeamName)
-	}
-
-	// for each fo Team A's opponents, compute the opponent's wins and ties as compared to the opponent's total
-	// games played, excluding games against Team A
-	opponentsNames, err := s.GetOpponents(targetTeamName)
-	if err != nil {
+// CalculateOWP calculates the opponents' winning percentage for the specified team.
+func (s *Schedule) CalculateOWP(teamName string) (float64, error) {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
 		return 0.0, err
 	}
 
-	// for each opponent, compute the opponent's wins and ties as compared to the opponent's total games played,
-	opponentWinningPercentageMap := make(map[string]float64)
-	for _, opponentName := range opponentsNames {
-		// compute the wins for this opponent
-		opponentWins, err := s.GetWinsForTeam(opponentName, targetTeamName)
-		if err != nil {
-			return 0.0, err
-		}
-
-		// compute the losses for this opponent
-		opponentLosses, err := s.GetLossesForTeam(opponentName, targetTeamName)
-		if err != nil {
-			return 0.0, err
-		}
-
-		// compute the ties for this opponent
-		opponentTies, err := s.GetTiesForTeam(opponentName, targetTeamName)
-		if err != nil {
-			return 0.0, err
-		}
-
-		// determine the total games played by this opponent
-		totalMatchesPlayed := opponentWins + opponentLosses + opponentTies
-
-		// determine the opponent's winning percentage
-		opponentWP := (float64(opponentWins) + 0.5*float64(opponentTies)) / float64(totalMatchesPlayed)
-
-		// store the opponent's winning percentage
-		opponentWinningPercentageMap[opponentName] = opponentWP
-	}
-
-	// at this point we should have the winning percentage for each of Team A's opponents
-	// now we need to determine the average of these winning percentages
-
-	// determine the sum of the winning percentages
-	// Note: this calculation is a bit tricky because it depends on the number of meetings between the target team
-	// and the opponent.  For example, if Team A has played Team B 3 times and Team C 2 times, then the sum of the
-	// winning percentages is:
-	//   (Team B's WP * 3) + (Team C's WP * 2)
-	// but you also have to keep track of the total matches played with respect to each opponent.  For example, if
-	// Team B has played 3 matches against Team A and 2 matches against Team C, then the total matches played is:
-	//   3 + 2 = 5
-	var sum float64
-	numberOfMatches := 0
-	for opponentName, wp := range opponentWinningPercentageMap {
-		meetingCount, err := s.GetMeetingCount(targetTeamName, opponentName)
-		numberOfMatches += meetingCount
-		if err != nil {
-			return 0.0, err
-		}
-
-		sum += wp * float64(meetingCount)
-	}
-
-	// determine the average of the winning percentages
-	average := sum / float64(numberOfMatches)
-
-	return average, nil
+	return NewElement(v.tally().owp(teamName)).Float(), nil
 }
 
 // CalculateOOWP calculates the opponent's opponent's winning percentage for the specified team.
-// The opponent's opponent's winning percentage is the average of the winning percentages of all of the
+// The opponent's opponent's winning percentage is the average of the opponents' winning percentages of all of the
 // opponents of the specified team.
 func (s *Schedule) CalculateOOWP(teamName string) (float64, error) {
-	var err error
-	var owp float64
-
-	if teamName == "" {
-		return 0.0, fmt.Errorf("the specified team name is empty")
-	}
-
-	if !s.Contains(teamName) {
-		return 0.0, fmt.Errorf("no matches found for team %s", teamName)
-	}
-
-	opponents, err := s.GetOpponents(teamName)
-	if err != nil {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
 		return 0.0, err
 	}
 
-	opponentsOWPMap := make(map[string]float64)
-	for _, opponent := range opponents {
-		if owp, err = s.CalculateOWP(opponent); err != nil {
-			return 0.0, err
-		}
-
-		opponentsOWPMap[opponent] = owp
-	}
-
-	var accumulator float64
-	var numberOfMatches int
-
-	for opponent, owp := range opponentsOWPMap {
-		var numberOfMeetings int
-		if numberOfMeetings, err = s.GetMeetingCount(teamName, opponent); err != nil {
-			return 0.0, err
-		}
-
-		numberOfMatches += numberOfMeetings
-		accumulator += owp * float64(numberOfMeetings)
-	}
-
-	average := accumulator / float64(numberOfMatches)
-
-	return average, nil
+	return NewElement(v.tally().oowp(teamName)).Float(), nil
 }
 
+// CalculateRPI calculates the RPI for the specified team using the schedule's formula.
 func (s *Schedule) CalculateRPI(teamName string) (float64, error) {
-	var err error
-	var wp, owp, oowp, rpi float64
-
-	if wp, err = s.CalculateWP(teamName, ""); err != nil {
+	v := s.snapshot()
+	if err := v.checkRatedTeam(teamName); err != nil {
 		return 0.0, err
 	}
 
-	if owp, err = s.CalculateOWP(teamName); err != nil {
-		return 0.0, err
-	}
-
-	if oowp, err = s.CalculateOOWP(teamName); err != nil {
-		return 0.0, err
-	}
-
-	rpi = (wp + (float64(2) * owp) + oowp) / float64(4)
-
-	return rpi, nil
+	return NewElement(v.tally().rpi(teamName)).Float(), nil
 }
@@ -1,6 +1,7 @@
 package schedule_test
 
 import (
+	"github.com/jedi-knights/rpi/pkg/match"
 	"github.com/jedi-knights/rpi/pkg/schedule"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -70,6 +71,24 @@ var _ = Describe("Schedule", func() {
 		})
 	})
 
+	Describe("GetTeams", func() {
+		It("should return every team in the order they first appear", func() {
+			// Act
+			teams := pSchedule.GetTeams()
+
+			// Assert
+			Expect(teams).To(Equal([]string{"UConn", "Kansas", "Duke", "Wisconsin"}))
+		})
+
+		It("should return an empty slice for an empty schedule", func() {
+			// Act
+			teams := schedule.NewSchedule().GetTeams()
+
+			// Assert
+			Expect(teams).To(BeEmpty())
+		})
+	})
+
 	Describe("GetOpponents", func() {
 		It("should return all opponents for UConn", func() {
 			// Act
@@ -770,4 +789,81 @@ var _ = Describe("Schedule", func() {
 			Expect(err.Error()).To(Equal("no matches found for team Foo"))
 		})
 	})
+
+	Describe("Snapshot", func() {
+		It("should keep the matches the schedule had when it was taken", func() {
+			// Arrange
+			snapshot := pSchedule.Snapshot()
+			before := pSchedule.GetMatches()
+
+			// Act
+			Expect(pSchedule.AddMatchFromString("2023-12-01,Gonzaga,70,Duke,60")).To(Succeed())
+			_, err := pSchedule.RemoveMatchByID(before[0].ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			Expect(snapshot.GetMatches()).To(Equal(before))
+			Expect(snapshot.GetFormula()).To(Equal(pSchedule.GetFormula()))
+			Expect(snapshot.GetMatch(before[0].ID)).To(BeIdenticalTo(before[0]))
+		})
+	})
+
+	Describe("ReplaceAll", func() {
+		var replacements []*match.Match
+
+		BeforeEach(func() {
+			replacements = []*match.Match{
+				match.NewMatchFromString("2023-12-01,Gonzaga,70,Duke,60"),
+				match.NewMatchFromString("2023-12-02,Duke,65,Gonzaga,64"),
+			}
+		})
+
+		It("should put the matches in place of every match", func() {
+			// Arrange
+			old := pSchedule.GetMatchesForTeam("Wisconsin")[0]
+
+			// Act
+			err := pSchedule.ReplaceAll(replacements)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pSchedule.GetMatches()).To(Equal(replacements))
+			Expect(pSchedule.GetTeams()).To(Equal([]string{"Gonzaga", "Duke"}))
+			Expect(pSchedule.GetMatch("2023-12-01-gonzaga-duke")).To(BeIdenticalTo(replacements[0]))
+
+			_, err = pSchedule.GetMatch(old.ID)
+			Expect(err).To(MatchError("no match with id " + old.ID))
+		})
+
+		It("should change nothing when two matches share an ID", func() {
+			// Arrange
+			before := pSchedule.GetMatches()
+			replacements[0].ID = "same"
+			replacements[1].ID = "same"
+
+			// Act
+			err := pSchedule.ReplaceAll(replacements)
+
+			// Assert
+			Expect(err).To(MatchError("a match with id same already exists"))
+			Expect(pSchedule.GetMatches()).To(Equal(before))
+		})
+
+		It("should restore the old matches when the store fails", func() {
+			// Arrange
+			store := &limitedStore{MemoryStore: schedule.NewMemoryStore(), adds: 3}
+			pSchedule = schedule.NewScheduleWithStore(store)
+			Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")).To(Succeed())
+			before := pSchedule.GetMatches()
+			store.adds = 1
+
+			// Act
+			err := pSchedule.ReplaceAll(replacements)
+
+			// Assert
+			Expect(err).To(MatchError("the store is full"))
+			Expect(pSchedule.GetMatches()).To(Equal(before))
+			Expect(pSchedule.GetMatch("2023-11-06-uconn-kansas")).To(BeIdenticalTo(before[0]))
+		})
+	})
 })
@@ -0,0 +1,37 @@
+package schedule_test
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// generateSeason builds a reproducible schedule of random results between the specified number of teams.
+// Scores range from 0 to 4 so that roughly one match in five is a draw, as in a soccer season.
+func generateSeason(seed int64, numberOfTeams, numberOfMatches int) *schedule.Schedule {
+	rng := rand.New(rand.NewSource(seed))
+	factory := match.NewFactory(match.NewBuilder())
+	start := time.Date(2023, time.August, 17, 0, 0, 0, 0, time.UTC)
+
+	teams := make([]string, numberOfTeams)
+	for i := range teams {
+		teams[i] = fmt.Sprintf("Team %03d", i+1)
+	}
+
+	s := schedule.NewSchedule()
+	for i := 0; i < numberOfMatches; i++ {
+		home := rng.Intn(numberOfTeams)
+		away := rng.Intn(numberOfTeams - 1)
+		if away >= home {
+			away++
+		}
+
+		date := start.AddDate(0, 0, i*90/numberOfMatches)
+		s.AddMatch(factory.Create(date, teams[home], rng.Intn(5), teams[away], rng.Intn(5)))
+	}
+
+	return s
+}
@@ -0,0 +1,339 @@
+package schedule
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"sync"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Model gives the chances of each result of a fixture from the current ratings of its teams.  A rating
+// is nil when the team isn't rated yet.
+type Model interface {
+	// Probabilities returns the chance that the home team wins and the chance that the match is tied.
+	// The away team wins otherwise.
+	Probabilities(fixture *Match, home, away *Rating) (homeWin, tie float64)
+}
+
+// LogisticModel ties a fixture at a fixed rate and otherwise favors the team with the higher RPI, with
+// the chance of a home win following a logistic curve over the difference in RPI.
+type LogisticModel struct {
+	// Tie is the chance of a tie.
+	Tie float64
+
+	// Scale is the RPI difference that makes the favorite e times as likely to win as to lose.
+	Scale float64
+
+	// HomeAdvantage is added to the home team's RPI unless the fixture is at a neutral site.
+	HomeAdvantage float64
+}
+
+// DefaultModel is the model used when a simulation doesn't name one.  Ties are common enough in soccer
+// that one match in five is drawn.
+var DefaultModel = LogisticModel{Tie: 0.2, Scale: 0.05, HomeAdvantage: 0.01}
+
+// Probabilities returns the chances of a home win and of a tie.  When either team isn't rated the
+// decided matches are split evenly.
+func (m LogisticModel) Probabilities(fixture *Match, home, away *Rating) (float64, float64) {
+	decided := 1 - m.Tie
+	if home == nil || away == nil || math.IsNaN(home.RPI) || math.IsNaN(away.RPI) {
+		return decided / 2, m.Tie
+	}
+
+	difference := home.RPI - away.RPI
+	if !fixture.Neutral {
+		difference += m.HomeAdvantage
+	}
+
+	return decided / (1 + math.Exp(-difference/m.Scale)), m.Tie
+}
+
+// SimulationOptions controls a simulation.
+type SimulationOptions struct {
+	// Iterations is the number of seasons simulated.
+	Iterations int
+
+	// Seed seeds the random results, so the same seed gives the same simulation whatever the number
+	// of workers.
+	Seed int64
+
+	// Workers is the number of seasons simulated at once.  The number of CPUs is used when it is zero.
+	Workers int
+
+	// Model gives the chances of each result.  DefaultModel is used when it is nil.
+	Model Model
+}
+
+// TeamSimulation is the spread of a team's finishes over every simulated season.
+type TeamSimulation struct {
+	Team string
+
+	// Ranks counts the seasons the team finished at each rank, so Ranks[0] is the number of seasons it
+	// finished first.
+	Ranks []int
+
+	// MedianRPI is the team's median RPI at the end of the season, or NaN when it is never defined.
+	MedianRPI float64
+
+	// MeanRank is the team's average rank, and BestRank and WorstRank its highest and lowest.
+	MeanRank  float64
+	BestRank  int
+	WorstRank int
+}
+
+// Iterations returns the number of simulated seasons.
+func (t *TeamSimulation) Iterations() int {
+	total := 0
+	for _, count := range t.Ranks {
+		total += count
+	}
+
+	return total
+}
+
+// Probability returns the share of seasons the team finished at or above the cutoff rank.
+func (t *TeamSimulation) Probability(cutoff int) float64 {
+	inside := 0
+	for rank := 0; rank < cutoff && rank < len(t.Ranks); rank++ {
+		inside += t.Ranks[rank]
+	}
+
+	return float64(inside) / float64(t.Iterations())
+}
+
+// TeamSimulations is the simulated finish of every team.
+type TeamSimulations []*TeamSimulation
+
+// Find returns the simulation of the specified team or nil if the team has none.
+func (t TeamSimulations) Find(teamName string) *TeamSimulation {
+	for _, simulation := range t {
+		if simulation.Team == teamName {
+			return simulation
+		}
+	}
+
+	return nil
+}
+
+// SortByRank orders the teams from the best average rank to the worst.  Equal ranks are ordered by
+// team name.
+func (t TeamSimulations) SortByRank() {
+	sortByValue(t, func(simulation *TeamSimulation) (string, float64) {
+		return simulation.Team, -simulation.MeanRank
+	})
+}
+
+// Simulation is the result of simulating the rest of a season many times.
+type Simulation struct {
+	Iterations int
+	Seed       int64
+
+	// Fixtures is the number of scheduled and postponed fixtures given a random result each season.
+	Fixtures int
+
+	Teams TeamSimulations
+}
+
+// fixtureChances is a fixture with the chances of each of its results.
+type fixtureChances struct {
+	fixture *Match
+	homeWin float64
+	tie     float64
+}
+
+// Simulate plays out every scheduled and postponed fixture many times, drawing each result from the
+// model's chances given the current ratings, and ranks the teams at the end of each simulated season.
+// Seasons are simulated in parallel.  Each season is seeded from the seed and its number, so the
+// result doesn't depend on how many seasons run at once.
+func (s *Schedule) Simulate(opts SimulationOptions) (*Simulation, error) {
+	if opts.Iterations < 1 {
+		return nil, fmt.Errorf("invalid iterations <%d>", opts.Iterations)
+	}
+
+	v := s.snapshot()
+
+	fixtures, err := v.chances(opts.model())
+	if err != nil {
+		return nil, err
+	}
+
+	played := v.played()
+	teams := v.simulatedTeams(played, fixtures)
+
+	index := make(map[string]int, len(teams))
+	for i, teamName := range teams {
+		index[teamName] = i
+	}
+
+	ranks := make([][]int, len(teams))
+	rpis := make([][]float64, len(teams))
+	for i := range teams {
+		ranks[i] = make([]int, opts.Iterations)
+		rpis[i] = make([]float64, opts.Iterations)
+	}
+
+	workers := opts.workers()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			for iteration := worker; iteration < opts.Iterations; iteration += workers {
+				random := rand.New(rand.NewSource(iterationSeed(opts.Seed, iteration)))
+
+				ratings := v.simulated(played, fixtures, random).all()
+				ratings.SortByRPI()
+
+				for rank, rating := range ratings {
+					ranks[index[rating.Team]][iteration] = rank + 1
+					rpis[index[rating.Team]][iteration] = rating.RPI
+				}
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	simulation := &Simulation{Iterations: opts.Iterations, Seed: opts.Seed, Fixtures: len(fixtures)}
+	for i, teamName := range teams {
+		simulation.Teams = append(simulation.Teams, summarize(teamName, len(teams), ranks[i], rpis[i]))
+	}
+
+	return simulation, nil
+}
+
+func (o SimulationOptions) model() Model {
+	if o.Model == nil {
+		return DefaultModel
+	}
+
+	return o.Model
+}
+
+func (o SimulationOptions) workers() int {
+	workers := o.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	return min(workers, o.Iterations)
+}
+
+// chances returns the chances of each result of every fixture still to be played.
+func (v *snapshot) chances(model Model) ([]fixtureChances, error) {
+	current := v.all()
+
+	var fixtures []fixtureChances
+	for _, fixture := range v.matches {
+		if !fixture.IsPending() {
+			continue
+		}
+
+		homeWin, tie := model.Probabilities(fixture, current.Find(fixture.Home.Name), current.Find(fixture.Away.Name))
+		if homeWin < 0 || tie < 0 || homeWin+tie > 1 || math.IsNaN(homeWin+tie) {
+			return nil, fmt.Errorf("invalid chances for %s: %v to win and %v to tie", fixture.ID, homeWin, tie)
+		}
+
+		fixtures = append(fixtures, fixtureChances{fixture: fixture, homeWin: homeWin, tie: tie})
+	}
+
+	return fixtures, nil
+}
+
+// simulatedTeams returns every team rated at the end of a simulated season.  Drawing every fixture
+// rates the same teams as any other results.
+func (v *snapshot) simulatedTeams(played []*Match, fixtures []fixtureChances) []string {
+	season := &snapshot{matches: slices.Clone(played), formula: v.formula, registry: v.registry}
+	for _, f := range fixtures {
+		draw := f.fixture.Clone()
+		draw.State = StateCompleted
+		season.matches = append(season.matches, draw)
+	}
+
+	var teams []string
+	for _, rating := range season.all() {
+		teams = append(teams, rating.Team)
+	}
+
+	return teams
+}
+
+// simulated returns a snapshot of the played matches with a random result drawn for every fixture.
+func (v *snapshot) simulated(played []*Match, fixtures []fixtureChances, random *rand.Rand) *snapshot {
+	matches := make([]*Match, len(played), len(played)+len(fixtures))
+	copy(matches, played)
+
+	for _, f := range fixtures {
+		result := f.fixture.Clone()
+		result.State = StateCompleted
+
+		switch draw := random.Float64(); {
+		case draw < f.homeWin:
+			result.Home.Score = 1
+		case draw >= f.homeWin+f.tie:
+			result.Away.Score = 1
+		}
+
+		matches = append(matches, result)
+	}
+
+	return &snapshot{matches: matches, formula: v.formula, registry: v.registry}
+}
+
+// iterationSeed mixes the simulation's seed with the iteration number so that every season has its
+// own well-spread seed.
+func iterationSeed(seed int64, iteration int) int64 {
+	z := uint64(seed) + uint64(iteration+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+
+	return int64(z ^ (z >> 31))
+}
+
+// summarize counts a team's finishes at each rank and finds its median RPI.
+func summarize(teamName string, teamCount int, ranks []int, rpis []float64) *TeamSimulation {
+	simulation := &TeamSimulation{Team: teamName, Ranks: make([]int, teamCount), BestRank: teamCount}
+
+	total := 0
+	for _, rank := range ranks {
+		simulation.Ranks[rank-1]++
+		simulation.BestRank = min(simulation.BestRank, rank)
+		simulation.WorstRank = max(simulation.WorstRank, rank)
+		total += rank
+	}
+
+	simulation.MeanRank = float64(total) / float64(len(ranks))
+	simulation.MedianRPI = median(rpis)
+
+	return simulation
+}
+
+// median returns the median of the defined values, or NaN when none is defined.
+func median(values []float64) float64 {
+	var defined []float64
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			defined = append(defined, value)
+		}
+	}
+
+	if len(defined) == 0 {
+		return math.NaN()
+	}
+
+	slices.Sort(defined)
+
+	middle := len(defined) / 2
+	if len(defined)%2 == 0 {
+		return (defined[middle-1] + defined[middle]) / 2
+	}
+
+	return defined[middle]
+}
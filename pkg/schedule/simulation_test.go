@@ -0,0 +1,197 @@
+package schedule_test
+
+import (
+	"math"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fixedModel gives every fixture the same chances.
+type fixedModel struct {
+	homeWin float64
+	tie     float64
+}
+
+func (m fixedModel) Probabilities(*match.Match, *schedule.Rating, *schedule.Rating) (float64, float64) {
+	return m.homeWin, m.tie
+}
+
+var _ = Describe("Simulation", func() {
+	var pSchedule *schedule.Schedule
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+
+		for _, line := range []string{
+			"2023-09-01,A,2,B,0",
+			"2023-09-02,C,1,D,0",
+			"2023-09-03,A,1,C,0",
+			"2023-09-04,B,1,D,1",
+			"2023-09-05,D,2,E,0",
+			"2023-09-06,E,1,B,0",
+			"2023-09-07,C,2,E,2",
+			"2023-09-10,D,,A,",
+			"2023-09-11,B,,C,",
+			"2023-09-12,E,,A,",
+		} {
+			Expect(pSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+	})
+
+	Describe("Simulate", func() {
+		It("should rank every team in every season", func() {
+			// Act
+			simulation, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 200, Seed: 7})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(simulation.Iterations).To(Equal(200))
+			Expect(simulation.Seed).To(Equal(int64(7)))
+			Expect(simulation.Fixtures).To(Equal(3))
+			Expect(simulation.Teams).To(HaveLen(5))
+
+			for _, team := range simulation.Teams {
+				Expect(team.Ranks).To(HaveLen(5))
+				Expect(team.Iterations()).To(Equal(200))
+				Expect(team.Probability(5)).To(Equal(1.0))
+				Expect(team.BestRank).To(BeNumerically("<=", team.MeanRank))
+				Expect(float64(team.WorstRank)).To(BeNumerically(">=", team.MeanRank))
+				Expect(math.IsNaN(team.MedianRPI)).To(BeFalse())
+			}
+		})
+
+		It("should give the same results for the same seed whatever the number of workers", func() {
+			// Arrange
+			expected, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 100, Seed: 42, Workers: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			simulation, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 100, Seed: 42, Workers: 4})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(simulation).To(Equal(expected))
+		})
+
+		It("should give different results for different seeds", func() {
+			// Arrange
+			first, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 100, Seed: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			second, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 100, Seed: 2})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Teams).NotTo(Equal(first.Teams))
+		})
+
+		It("should play every season the same way when the results are certain", func() {
+			// Arrange
+			projection, err := pSchedule.Project(schedule.Outcomes{
+				"2023-09-10-d-a": "1-0",
+				"2023-09-11-b-c": "1-0",
+				"2023-09-12-e-a": "1-0",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			projection.Ratings.SortByRPI()
+
+			// Act
+			simulation, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 20, Model: fixedModel{homeWin: 1}})
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+
+			for i, rating := range projection.Ratings {
+				team := simulation.Teams.Find(rating.Team)
+				Expect(team.BestRank).To(Equal(i + 1))
+				Expect(team.WorstRank).To(Equal(i + 1))
+				Expect(team.Ranks[i]).To(Equal(20))
+				Expect(team.MedianRPI).To(Equal(rating.RPI))
+			}
+		})
+
+		It("should order the teams by their average rank", func() {
+			// Arrange
+			simulation, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 100, Seed: 3})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Act
+			simulation.Teams.SortByRank()
+
+			// Assert
+			for i := 1; i < len(simulation.Teams); i++ {
+				Expect(simulation.Teams[i].MeanRank).To(BeNumerically(">=", simulation.Teams[i-1].MeanRank))
+			}
+		})
+
+		It("should count the seasons inside a cutoff", func() {
+			// Arrange
+			team := &schedule.TeamSimulation{Team: "A", Ranks: []int{6, 3, 1, 0}}
+
+			// Act
+			probability := team.Probability(2)
+
+			// Assert
+			Expect(team.Iterations()).To(Equal(10))
+			Expect(probability).To(Equal(0.9))
+			Expect(team.Probability(0)).To(Equal(0.0))
+			Expect(team.Probability(9)).To(Equal(1.0))
+		})
+
+		It("should reject too few iterations", func() {
+			// Act
+			_, err := pSchedule.Simulate(schedule.SimulationOptions{})
+
+			// Assert
+			Expect(err).To(MatchError("invalid iterations <0>"))
+		})
+
+		It("should reject a model with impossible chances", func() {
+			// Act
+			_, err := pSchedule.Simulate(schedule.SimulationOptions{Iterations: 1, Model: fixedModel{homeWin: 0.8, tie: 0.3}})
+
+			// Assert
+			Expect(err).To(MatchError("invalid chances for 2023-09-10-d-a: 0.8 to win and 0.3 to tie"))
+		})
+	})
+
+	Describe("LogisticModel", func() {
+		model := schedule.LogisticModel{Tie: 0.2, Scale: 0.05, HomeAdvantage: 0.01}
+		fixture := &match.Match{Home: match.Status{Name: "A"}, Away: match.Status{Name: "B"}}
+
+		It("should favor the team with the higher RPI", func() {
+			// Act
+			homeWin, tie := model.Probabilities(fixture, &schedule.Rating{RPI: 0.6}, &schedule.Rating{RPI: 0.5})
+
+			// Assert
+			Expect(tie).To(Equal(0.2))
+			Expect(homeWin).To(BeNumerically("~", 0.8/(1+math.Exp(-0.11/0.05)), 1e-12))
+			Expect(homeWin).To(BeNumerically(">", 0.4))
+		})
+
+		It("should give no home advantage at a neutral site", func() {
+			// Arrange
+			neutral := fixture.Clone()
+			neutral.Neutral = true
+
+			// Act
+			homeWin, _ := model.Probabilities(neutral, &schedule.Rating{RPI: 0.5}, &schedule.Rating{RPI: 0.5})
+
+			// Assert
+			Expect(homeWin).To(Equal(0.4))
+		})
+
+		It("should split the decided matches evenly when a team isn't rated", func() {
+			// Act
+			homeWin, tie := model.Probabilities(fixture, &schedule.Rating{RPI: 0.6}, nil)
+
+			// Assert
+			Expect(homeWin).To(Equal(0.4))
+			Expect(tie).To(Equal(0.2))
+		})
+	})
+})
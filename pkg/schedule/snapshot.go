@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/team"
+)
+
+// snapshot is a copy of a schedule's matches and settings taken at a single point in time.  Each
+// query works from one snapshot so that it never sees a match that is added or removed while it runs.
+type snapshot struct {
+	matches  []*Match
+	formula  Formula
+	registry *team.Registry
+}
+
+// snapshot copies the schedule's matches and settings while holding the read lock.
+func (s *Schedule) snapshot() *snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &snapshot{
+		matches:  slices.Clone(s.store.Matches()),
+		formula:  s.formula,
+		registry: s.registry,
+	}
+}
+
+// Snapshot returns a schedule with the matches, formula and registry the schedule has now, so that
+// several queries can be answered from the same matches while the schedule goes on changing.  Like
+// AsOf, the schedules share the matches themselves, but adding or removing matches changes only one.
+func (s *Schedule) Snapshot() *Schedule {
+	v := s.snapshot()
+
+	copied := NewScheduleWithStore(NewMemoryStore(v.matches...))
+	copied.formula = v.formula
+	copied.registry = v.registry
+
+	return copied
+}
+
+// isEligible reports whether the team's matches against other eligible teams count toward the RPI.
+func (v *snapshot) isEligible(teamName string) bool {
+	return v.registry == nil || v.registry.IsEligible(teamName)
+}
+
+// played returns the matches that have been played.
+func (v *snapshot) played() []*Match {
+	var matches []*Match
+	for _, match := range v.matches {
+		if match.IsPlayed() {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// ratedMatches returns the played matches between two eligible teams.
+func (v *snapshot) ratedMatches() []*Match {
+	var matches []*Match
+	for _, match := range v.matches {
+		if match.IsPlayed() && v.isEligible(match.Home.Name) && v.isEligible(match.Away.Name) {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches
+}
+
+// tally aggregates the rated matches.
+func (v *snapshot) tally() *tally {
+	return newTally(v.ratedMatches(), v.formula)
+}
+
+func (v *snapshot) contains(teamName string) bool {
+	for _, match := range v.matches {
+		if match.Contains(teamName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkTeam returns an error when the team name is empty or the team has not played a match.
+func (v *snapshot) checkTeam(teamName string) error {
+	if teamName == "" {
+		return fmt.Errorf("the specified team name is empty")
+	}
+
+	if !v.contains(teamName) {
+		return fmt.Errorf("no matches found for team %s", teamName)
+	}
+
+	return nil
+}
+
+// checkRatedTeam returns an error when the team can't be rated because it has not played a match or
+// is not eligible for the RPI.
+func (v *snapshot) checkRatedTeam(teamName string) error {
+	if err := v.checkTeam(teamName); err != nil {
+		return err
+	}
+
+	if !v.isEligible(teamName) {
+		return fmt.Errorf("team %s is not eligible for the RPI", teamName)
+	}
+
+	return nil
+}
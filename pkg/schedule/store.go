@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Store holds the matches of a schedule.  A schedule calculates its ratings from whatever its store
+// returns, so matches can be kept in memory, in a file or in a database.
+type Store interface {
+	// Add stores the match.
+	Add(match *Match) error
+
+	// Remove deletes the match, which is identified by its pointer.
+	Remove(match *Match) error
+
+	// Replace swaps the match, which is identified by its pointer, for the replacement without
+	// changing its position.
+	Replace(match, replacement *Match) error
+
+	// Matches returns every stored match in the order it was added.  Callers must not modify the
+	// returned slice.
+	Matches() []*Match
+}
+
+// MemoryStore is a Store that keeps its matches in memory.
+type MemoryStore struct {
+	matches []*Match
+}
+
+func NewMemoryStore(matches ...*Match) *MemoryStore {
+	return &MemoryStore{
+		matches: slices.Clone(matches),
+	}
+}
+
+func (s *MemoryStore) Add(match *Match) error {
+	if match == nil {
+		return fmt.Errorf("the specified match is nil")
+	}
+
+	s.matches = append(s.matches, match)
+
+	return nil
+}
+
+func (s *MemoryStore) Remove(match *Match) error {
+	index := slices.Index(s.matches, match)
+	if index < 0 {
+		return fmt.Errorf("the specified match was not found")
+	}
+
+	s.matches = slices.Delete(s.matches, index, index+1)
+
+	return nil
+}
+
+func (s *MemoryStore) Replace(match, replacement *Match) error {
+	if replacement == nil {
+		return fmt.Errorf("the specified match is nil")
+	}
+
+	index := slices.Index(s.matches, match)
+	if index < 0 {
+		return fmt.Errorf("the specified match was not found")
+	}
+
+	s.matches[index] = replacement
+
+	return nil
+}
+
+func (s *MemoryStore) Matches() []*Match {
+	return s.matches
+}
@@ -0,0 +1,173 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeStore is a read-only store that serves a fixed set of matches.
+type fakeStore struct {
+	matches []*match.Match
+	reads   int
+}
+
+func (f *fakeStore) Add(*match.Match) error {
+	return fmt.Errorf("the store is read-only")
+}
+
+func (f *fakeStore) Remove(*match.Match) error {
+	return fmt.Errorf("the store is read-only")
+}
+
+func (f *fakeStore) Replace(*match.Match, *match.Match) error {
+	return fmt.Errorf("the store is read-only")
+}
+
+func (f *fakeStore) Matches() []*match.Match {
+	f.reads++
+	return f.matches
+}
+
+// limitedStore is a store in memory that fails once it has been asked to add a number of matches.
+type limitedStore struct {
+	*schedule.MemoryStore
+	adds int
+}
+
+func (l *limitedStore) Add(m *match.Match) error {
+	if l.adds == 0 {
+		return fmt.Errorf("the store is full")
+	}
+
+	l.adds--
+
+	return l.MemoryStore.Add(m)
+}
+
+var _ = Describe("Store", func() {
+	Describe("MemoryStore", func() {
+		var store *schedule.MemoryStore
+		var first, second *match.Match
+
+		BeforeEach(func() {
+			first = match.NewMatchFromString("Team A,1,Team B,0")
+			second = match.NewMatchFromString("Team B,2,Team C,2")
+			store = schedule.NewMemoryStore(first)
+		})
+
+		It("should return the matches in the order they were added", func() {
+			// Act
+			Expect(store.Add(second)).To(Succeed())
+
+			// Assert
+			Expect(store.Matches()).To(Equal([]*match.Match{first, second}))
+		})
+
+		It("should reject a nil match", func() {
+			// Act
+			err := store.Add(nil)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the specified match is nil"))
+		})
+
+		It("should remove a match", func() {
+			// Arrange
+			Expect(store.Add(second)).To(Succeed())
+
+			// Act
+			err := store.Remove(first)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Matches()).To(Equal([]*match.Match{second}))
+		})
+
+		It("should return an error when removing a match it doesn't hold", func() {
+			// Act
+			err := store.Remove(second)
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the specified match was not found"))
+		})
+	})
+
+	Describe("Schedule", func() {
+		It("should rate the matches in any store", func() {
+			// Arrange
+			store := &fakeStore{matches: []*match.Match{
+				match.NewMatchFromString("Team A,1,Team B,0"),
+				match.NewMatchFromString("Team B,2,Team C,1"),
+				match.NewMatchFromString("Team C,0,Team A,3"),
+			}}
+			pSchedule := schedule.NewScheduleWithStore(store)
+
+			reference := schedule.NewSchedule()
+			for _, m := range store.matches {
+				Expect(reference.AddMatch(m)).To(Succeed())
+			}
+
+			// Act
+			ratings, err := pSchedule.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+			expected, err := reference.CalculateAll()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Assert
+			Expect(ratings).To(Equal(expected))
+			Expect(store.reads).To(BeNumerically(">", 0))
+		})
+
+		It("should return the store's error", func() {
+			// Arrange
+			pSchedule := schedule.NewScheduleWithStore(&fakeStore{})
+
+			// Act
+			err := pSchedule.AddMatchFromString("Team A,1,Team B,0")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the store is read-only"))
+		})
+
+		It("should return an error for a match that doesn't parse", func() {
+			// Arrange
+			pSchedule := schedule.NewSchedule()
+
+			// Act
+			err := pSchedule.AddMatchFromString("Team A,1,Team B")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`unable to parse match "Team A,1,Team B"`))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(0))
+		})
+
+		It("should stop rating a removed match", func() {
+			// Arrange
+			pSchedule := schedule.NewSchedule()
+			Expect(pSchedule.AddMatchFromString("Team A,1,Team B,0")).To(Succeed())
+			Expect(pSchedule.AddMatchFromString("Team A,0,Team B,2")).To(Succeed())
+
+			// Act
+			err := pSchedule.RemoveMatch(pSchedule.GetMatches()[1])
+			Expect(err).NotTo(HaveOccurred())
+			wp, err := pSchedule.CalculateWP("Team A", "")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wp).To(Equal(1.0))
+		})
+
+		It("should satisfy ISchedule", func() {
+			var s schedule.ISchedule = schedule.NewSchedule()
+			Expect(s.GetTotalMatchesPlayed()).To(Equal(0))
+		})
+	})
+})
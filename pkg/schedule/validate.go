@@ -0,0 +1,127 @@
+package schedule
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	. "github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/validation"
+)
+
+// AddMatches validates the matches among themselves and against the schedule's current matches and
+// applies the policy: problems are reported, or cause nothing to be added, or decide which report of
+// a repeated match is kept.  Only the new matches are judged, so problems already in the schedule are
+// neither reported nor acted on.  A match already in the schedule is only removed under
+// validation.PolicyKeepLast, when a later report of the same meeting is added.  Nothing is changed
+// when the store can't add or remove a match.  It returns the problems found and, under
+// validation.PolicyReject, an error when there are any.
+func (s *Schedule) AddMatches(matches []*Match, policy validation.Policy) (validation.Issues, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := slices.Clone(s.store.Matches())
+
+	issues := newIssues(existing, matches)
+	if policy == validation.PolicyReject && len(issues) > 0 {
+		return issues, issues
+	}
+
+	kept, _, _ := validation.Validate(matches, policy)
+
+	meetings := make(map[string][]*Match)
+	for _, match := range existing {
+		key := validation.MeetingKey(match)
+		meetings[key] = append(meetings[key], match)
+	}
+
+	var added []*Match
+	removed := make(map[*Match]bool)
+	for _, match := range kept {
+		key := validation.MeetingKey(match)
+		reports := meetings[key]
+
+		switch {
+		case len(reports) > 0 && policy == validation.PolicyKeepFirst:
+			continue
+		case len(reports) > 0 && policy == validation.PolicyKeepLast:
+			for _, report := range reports {
+				removed[report] = true
+			}
+			delete(meetings, key)
+		}
+
+		added = append(added, match)
+	}
+
+	if err := s.checkNewIDs(added, removed); err != nil {
+		return issues, err
+	}
+
+	ids := maps.Clone(s.ids)
+
+	var replaced []*Match
+	for _, match := range existing {
+		if removed[match] {
+			replaced = append(replaced, match)
+			delete(s.ids, match.ID)
+		}
+	}
+
+	// The new matches are added before the replaced ones are removed so that a store that can't add a
+	// match is rolled back by removing matches alone.
+	for i, match := range added {
+		if err := s.add(match); err != nil {
+			s.rollback(added[:i], nil, ids)
+			return issues, err
+		}
+	}
+
+	for i, match := range replaced {
+		if err := s.store.Remove(match); err != nil {
+			s.rollback(added, replaced[:i], ids)
+			return issues, err
+		}
+	}
+
+	return issues, nil
+}
+
+// newIssues returns the problems with the new matches, found by validating them after the existing
+// matches.  Problems among the existing matches alone are left out.
+func newIssues(existing, matches []*Match) validation.Issues {
+	_, all, _ := validation.Validate(append(slices.Clone(existing), matches...), validation.PolicyReport)
+
+	incoming := make(map[*Match]bool, len(matches))
+	for _, match := range matches {
+		incoming[match] = true
+	}
+
+	var issues validation.Issues
+	for _, issue := range all {
+		if incoming[issue.Match] {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// checkNewIDs returns an error when a match to be added has an ID that another added match uses or
+// that a match staying in the schedule uses.
+func (s *Schedule) checkNewIDs(added []*Match, removed map[*Match]bool) error {
+	seen := make(map[string]bool)
+	for _, match := range added {
+		if match.ID == "" {
+			continue
+		}
+
+		if other := s.ids[match.ID]; seen[match.ID] || (other != nil && !removed[other]) {
+			return fmt.Errorf("a match with id %s already exists", match.ID)
+		}
+
+		seen[match.ID] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package schedule_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AddMatches", func() {
+	var pSchedule *schedule.Schedule
+	var incoming []*match.Match
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+		Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")).To(Succeed())
+
+		incoming = []*match.Match{
+			match.NewMatchFromString("2023-11-10,UConn,82,Duke,68"),
+			match.NewMatchFromString("2023-11-06,Kansas,58,UConn,64"),
+		}
+	})
+
+	It("should report repeats of the schedule's matches and keep everything", func() {
+		// Act
+		issues, err := pSchedule.AddMatches(incoming, validation.PolicyReport)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0].Kind).To(Equal(validation.KindConflict))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(3))
+		Expect(pSchedule.GetMeetingCount("UConn", "Kansas")).To(Equal(2))
+	})
+
+	It("should add nothing when rejecting", func() {
+		// Act
+		issues, err := pSchedule.AddMatches(incoming, validation.PolicyReject)
+
+		// Assert
+		Expect(err).To(MatchError("2023-11-06 Kansas,58,UConn,64: conflicts with 2023-11-06 UConn,64,Kansas,57"))
+		Expect(issues).To(HaveLen(1))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(1))
+	})
+
+	It("should keep the match already in the schedule", func() {
+		// Act
+		_, err := pSchedule.AddMatches(incoming, validation.PolicyKeepFirst)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(2))
+		Expect(pSchedule.GetMatch("2023-11-06-uconn-kansas")).To(HaveField("Away.Score", 57))
+	})
+
+	It("should replace the match already in the schedule with the last report", func() {
+		// Act
+		_, err := pSchedule.AddMatches(incoming, validation.PolicyKeepLast)
+
+		// Assert
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(2))
+		Expect(pSchedule.GetMeetingCount("UConn", "Kansas")).To(Equal(1))
+		Expect(pSchedule.GetRecord("Kansas")).To(Equal(schedule.Record{Losses: 1}))
+		Expect(pSchedule.GetMatches()[1].ID).To(Equal("2023-11-06-kansas-uconn"))
+	})
+
+	Describe("problems already in the schedule", func() {
+		BeforeEach(func() {
+			pSchedule = schedule.NewSchedule()
+			for _, line := range []string{"2023-05-01,A,1,B,0", "2023-05-01,A,3,B,2", "2023-05-02,C,1,D,0"} {
+				Expect(pSchedule.AddMatchFromString(line)).To(Succeed())
+			}
+
+			incoming = []*match.Match{match.NewMatchFromString("2023-05-03,E,1,F,0")}
+		})
+
+		DescribeTable("should keep every existing match when unrelated matches are added",
+			func(policy validation.Policy) {
+				// Act
+				issues, err := pSchedule.AddMatches(incoming, policy)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(issues).To(BeEmpty())
+				Expect(pSchedule.GetMatches()).To(HaveLen(4))
+				Expect(pSchedule.GetMatch("2023-05-01-a-b")).To(HaveField("Home.Score", 1))
+				Expect(pSchedule.GetMatch("2023-05-01-a-b-2")).To(HaveField("Home.Score", 3))
+			},
+			Entry("reporting", validation.PolicyReport),
+			Entry("rejecting", validation.PolicyReject),
+			Entry("keeping the first report", validation.PolicyKeepFirst),
+			Entry("keeping the last report", validation.PolicyKeepLast),
+		)
+
+		It("should replace every earlier report of a meeting with the last report", func() {
+			// Arrange
+			incoming = []*match.Match{match.NewMatchFromString("2023-05-01,B,0,A,0")}
+
+			// Act
+			issues, err := pSchedule.AddMatches(incoming, validation.PolicyKeepLast)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(HaveLen(1))
+			Expect(pSchedule.GetMatches()).To(HaveLen(2))
+			Expect(pSchedule.GetMeetingCount("A", "B")).To(Equal(1))
+			Expect(pSchedule.GetMatch("2023-05-02-c-d")).NotTo(BeNil())
+		})
+	})
+
+	It("should change nothing when the store can't add every match", func() {
+		// Arrange
+		pSchedule = schedule.NewScheduleWithStore(&limitedStore{MemoryStore: schedule.NewMemoryStore(), adds: 2})
+		Expect(pSchedule.AddMatchFromString("2023-11-06,UConn,64,Kansas,57")).To(Succeed())
+		before := pSchedule.GetMatches()
+
+		// Act
+		_, err := pSchedule.AddMatches(incoming, validation.PolicyKeepLast)
+
+		// Assert
+		Expect(err).To(MatchError("the store is full"))
+		Expect(pSchedule.GetMatches()).To(Equal(before))
+		Expect(pSchedule.GetMatch("2023-11-06-uconn-kansas")).To(HaveField("Away.Score", 57))
+		_, err = pSchedule.GetMatch("2023-11-10-uconn-duke")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should add nothing when a kept match has a taken ID", func() {
+		// Arrange
+		incoming[1] = match.NewMatchFromString("2023-11-12,Duke,1,Kansas,0")
+		incoming[1].ID = "2023-11-06-uconn-kansas"
+
+		// Act
+		_, err := pSchedule.AddMatches(incoming, validation.PolicyKeepFirst)
+
+		// Assert
+		Expect(err).To(MatchError("a match with id 2023-11-06-uconn-kansas already exists"))
+		Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(1))
+	})
+})
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// ranking is a single team's entry in the ranking.
+type ranking struct {
+	Rank        int      `json:"rank"`
+	Team        string   `json:"team"`
+	Wins        int      `json:"wins"`
+	Losses      int      `json:"losses"`
+	Ties        int      `json:"ties"`
+	WP          *float64 `json:"wp"`
+	OWP         *float64 `json:"owp"`
+	OOWP        *float64 `json:"oowp"`
+	RPI         *float64 `json:"rpi"`
+	Bonus       *float64 `json:"bonus,omitempty"`
+	Penalty     *float64 `json:"penalty,omitempty"`
+	AdjustedRPI *float64 `json:"adjustedRpi,omitempty"`
+}
+
+func (srv *Server) handleRankings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	top, err := queryInt(r, "top")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	adjusted, err := queryBool(r, "adjusted")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var table schedule.AdjustmentTable
+	if adjusted {
+		table = schedule.WomensSoccerAdjustments
+	}
+
+	asOf, err := queryDate(r, "as_of")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s := srv.schedule
+	if !asOf.IsZero() {
+		s = s.AsOf(asOf)
+	}
+	ratings, err := s.CalculateAdjusted(table)
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	ratings.SortByAdjustedRPI()
+	if top > 0 && top < len(ratings) {
+		ratings = ratings[:top]
+	}
+
+	rankings := make([]ranking, 0, len(ratings))
+	for i, rating := range ratings {
+		entry := ranking{
+			Rank:   i + 1,
+			Team:   rating.Team,
+			Wins:   rating.Wins,
+			Losses: rating.Losses,
+			Ties:   rating.Ties,
+			WP:     number(rating.WP),
+			OWP:    number(rating.OWP),
+			OOWP:   number(rating.OOWP),
+			RPI:    number(rating.RPI),
+		}
+
+		if adjusted {
+			entry.Bonus = number(rating.Bonus)
+			entry.Penalty = number(rating.Penalty)
+			entry.AdjustedRPI = number(rating.AdjustedRPI)
+		}
+
+		rankings = append(rankings, entry)
+	}
+
+	writeJSON(w, http.StatusOK, rankings)
+}
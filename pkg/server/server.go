@@ -0,0 +1,287 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jedi-knights/rpi/pkg/document"
+	"github.com/jedi-knights/rpi/pkg/importer"
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/team"
+	"github.com/jedi-knights/rpi/pkg/validation"
+)
+
+// maxBodySize is the largest request body accepted when uploading matches.
+const maxBodySize = 32 << 20
+
+// Server exposes a schedule and its rankings as a JSON API:
+//
+//	GET    /matches          list every match
+//	POST   /matches          append matches sent as a JSON array or as CSV; accepts duplicates=policy
+//	PUT    /matches          replace every match with the matches sent; accepts duplicates=policy
+//	GET    /teams            list every team with its record
+//	GET    /teams/{name}     a team's record, splits, RPI breakdown and matches
+//	GET    /rankings         every team ranked by RPI; accepts top=N, adjusted=true and as_of=YYYY-MM-DD
+type Server struct {
+	// mu makes requests that store matches one at a time, so that each response describes its own change.
+	mu       sync.Mutex
+	schedule *schedule.Schedule
+	mux      *http.ServeMux
+}
+
+// New returns a server for the schedule.  The schedule may be changed by other goroutines while it is
+// served; every request reads a consistent view of its matches.
+func New(s *schedule.Schedule) *Server {
+	srv := &Server{
+		schedule: s,
+		mux:      http.NewServeMux(),
+	}
+
+	srv.mux.HandleFunc("/matches", srv.handleMatches)
+	srv.mux.HandleFunc("/teams", srv.handleTeams)
+	srv.mux.HandleFunc("/teams/", srv.handleTeam)
+	srv.mux.HandleFunc("/rankings", srv.handleRankings)
+	srv.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+	})
+
+	return srv
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.ServeHTTP(w, r)
+}
+
+// errorResponse is the body of every error response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+}
+
+// number returns a JSON number, or nil for an undefined value that JSON can't represent.
+func number(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+
+	return &value
+}
+
+func (srv *Server) handleMatches(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		srv.listMatches(w)
+	case http.MethodPost:
+		srv.storeMatches(w, r, false)
+	case http.MethodPut:
+		srv.storeMatches(w, r, true)
+	default:
+		methodNotAllowed(w, r, http.MethodGet, http.MethodPost, http.MethodPut)
+	}
+}
+
+func (srv *Server) listMatches(w http.ResponseWriter) {
+	stored := srv.schedule.GetMatches()
+
+	matches := make([]document.Match, 0, len(stored))
+	for _, m := range stored {
+		matches = append(matches, document.NewMatch(m))
+	}
+
+	writeJSON(w, http.StatusOK, matches)
+}
+
+// storeResponse is the body returned after matches are stored.  Issues describes the repeated or invalid
+// matches found, which were kept or dropped according to the duplicates policy.
+type storeResponse struct {
+	Added  int      `json:"added"`
+	Total  int      `json:"total"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+// storeMatches adds the matches in the request body, first removing every match when replace is true.
+// Repeated and invalid matches are handled by the policy named by the duplicates parameter, which is
+// report by default.  Nothing is stored unless every match in the body can be stored.
+func (srv *Server) storeMatches(w http.ResponseWriter, r *http.Request, replace bool) {
+	policy, err := queryPolicy(r, "duplicates")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	matches, err := readMatches(w, r, srv.schedule.GetRegistry())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	var issues validation.Issues
+	if replace {
+		issues, err = srv.replaceMatches(matches, policy)
+	} else {
+		issues, err = srv.schedule.AddMatches(matches, policy)
+	}
+
+	if err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	response := storeResponse{Total: len(srv.schedule.GetMatches())}
+	for _, m := range matches {
+		if stored, err := srv.schedule.GetMatch(m.ID); err == nil && stored == m {
+			response.Added++
+		}
+	}
+
+	for _, issue := range issues {
+		response.Issues = append(response.Issues, issue.Error())
+	}
+
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// replaceMatches validates the matches on their own and, when they can all be stored, puts them in
+// place of every match in the schedule at once.
+func (srv *Server) replaceMatches(matches []*match.Match, policy validation.Policy) (validation.Issues, error) {
+	kept, issues, err := validation.Validate(matches, policy)
+	if err != nil {
+		return issues, err
+	}
+
+	return issues, srv.schedule.ReplaceAll(kept)
+}
+
+// readMatches reads the matches in a request body, which is CSV when the content type is text/csv and a
+// JSON array of matches otherwise.  When there is a registry every team name must resolve through it
+// and is replaced with the team's canonical name.
+func readMatches(w http.ResponseWriter, r *http.Request, registry *team.Registry) ([]*match.Match, error) {
+	body := http.MaxBytesReader(w, r.Body, maxBodySize)
+
+	var teams importer.TeamResolver
+	if registry != nil {
+		teams = registry
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+		matches, errs := importer.ReadCSV(body, importer.Options{FileName: "request", Teams: teams})
+		if len(errs) > 0 {
+			return nil, errs
+		}
+
+		return matches, nil
+	}
+
+	var stored []document.Match
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&stored); err != nil {
+		return nil, fmt.Errorf("invalid matches: %w", err)
+	}
+
+	matches := make([]*match.Match, 0, len(stored))
+	for i, m := range stored {
+		if m.Home.Name == "" || m.Away.Name == "" {
+			return nil, fmt.Errorf("match %d: both team names are required", i+1)
+		}
+
+		converted := m.Match()
+		if teams != nil {
+			if err := importer.ResolveTeams(converted, teams); err != nil {
+				return nil, fmt.Errorf("match %d: %w", i+1, err)
+			}
+		}
+
+		matches = append(matches, converted)
+	}
+
+	return matches, nil
+}
+
+// queryInt returns the integer value of a query parameter, or zero when it is missing.
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s <%s>", name, value)
+	}
+
+	return n, nil
+}
+
+// queryPolicy returns the validation policy named by a query parameter, or PolicyReport when it is missing.
+func queryPolicy(r *http.Request, name string) (validation.Policy, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return validation.PolicyReport, nil
+	}
+
+	policy, err := validation.ParsePolicy(value)
+	if err != nil {
+		return validation.PolicyReport, fmt.Errorf("invalid %s <%s>", name, value)
+	}
+
+	return policy, nil
+}
+
+// queryDate returns the date of a query parameter in the form YYYY-MM-DD, or the zero time when it is
+// missing.
+func queryDate(r *http.Request, name string) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s <%s>", name, value)
+	}
+
+	return date, nil
+}
+
+// queryBool returns the boolean value of a query parameter, or false when it is missing.
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s <%s>", name, value)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,345 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+	"github.com/jedi-knights/rpi/pkg/server"
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var pSchedule *schedule.Schedule
+	var ts *httptest.Server
+
+	BeforeEach(func() {
+		pSchedule = schedule.NewSchedule()
+		for _, line := range []string{
+			"2023-11-06,UConn,64,Kansas,57",
+			"2023-11-10,UConn,82,Duke,68",
+			"2023-11-14,Wisconsin,71,UConn,72",
+			"2023-11-20,Kansas,69,UConn,62",
+			"2023-11-24,Duke,81,Wisconsin,70,N,Madison Square Garden,New York",
+			"2023-11-28,Wisconsin,52,Kansas,62",
+		} {
+			Expect(pSchedule.AddMatchFromString(line)).To(Succeed())
+		}
+
+		ts = httptest.NewServer(server.New(pSchedule))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	request := func(method, path, contentType, body string) (int, http.Header, []byte) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := ts.Client().Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return resp.StatusCode, resp.Header, data
+	}
+
+	get := func(path string, target any) int {
+		status, header, data := request(http.MethodGet, path, "", "")
+		Expect(header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(json.Unmarshal(data, target)).To(Succeed())
+		return status
+	}
+
+	type errorBody struct {
+		Error string `json:"error"`
+	}
+
+	Describe("GET /rankings", func() {
+		It("should rank every team", func() {
+			// Act
+			var rankings []map[string]any
+			status := get("/rankings", &rankings)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(rankings).To(HaveLen(4))
+			Expect(rankings[0]["team"]).To(Equal("UConn"))
+			Expect(rankings[0]["rank"]).To(BeNumerically("==", 1))
+			Expect(rankings[0]["rpi"]).To(BeNumerically("~", 0.6910, 0.0001))
+			Expect(rankings[0]).NotTo(HaveKey("adjustedRpi"))
+		})
+
+		It("should limit the ranking and apply the adjustments", func() {
+			// Act
+			var rankings []map[string]any
+			status := get("/rankings?top=2&adjusted=true", &rankings)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(rankings).To(HaveLen(2))
+			Expect(rankings[0]).To(HaveKey("adjustedRpi"))
+		})
+
+		It("should rank the teams as of a date", func() {
+			// Act
+			var rankings []map[string]any
+			status := get("/rankings?as_of=2023-11-14", &rankings)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(rankings).To(HaveLen(4))
+			Expect(rankings[0]["team"]).To(Equal("Duke"))
+			Expect(rankings[0]["rpi"]).To(BeNil())
+
+			var body errorBody
+			Expect(get("/rankings?as_of=yesterday", &body)).To(Equal(http.StatusBadRequest))
+			Expect(body.Error).To(Equal("invalid as_of <yesterday>"))
+		})
+
+		It("should reject an invalid parameter", func() {
+			// Act
+			var body errorBody
+			status := get("/rankings?top=many", &body)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(body.Error).To(Equal("invalid top <many>"))
+		})
+	})
+
+	Describe("GET /teams", func() {
+		It("should list every team with its record", func() {
+			// Act
+			var teams []struct {
+				Name   string          `json:"name"`
+				Record schedule.Record `json:"record"`
+			}
+			status := get("/teams", &teams)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(teams).To(HaveLen(4))
+			Expect(teams[0].Name).To(Equal("UConn"))
+			Expect(teams[0].Record).To(Equal(schedule.Record{Wins: 3, Losses: 1}))
+		})
+	})
+
+	Describe("GET /teams/{name}", func() {
+		It("should return the team's breakdown", func() {
+			// Act
+			var detail struct {
+				Name    string           `json:"name"`
+				Rank    int              `json:"rank"`
+				Teams   int              `json:"teams"`
+				Splits  schedule.Splits  `json:"splits"`
+				RPI     float64          `json:"rpi"`
+				Matches []map[string]any `json:"matches"`
+			}
+			status := get("/teams/Duke", &detail)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(detail.Name).To(Equal("Duke"))
+			Expect(detail.Rank).To(Equal(3))
+			Expect(detail.Teams).To(Equal(4))
+			Expect(detail.Splits.Neutral).To(Equal(schedule.Record{Wins: 1}))
+			Expect(detail.Matches).To(HaveLen(2))
+			Expect(detail.Matches[1]["location"]).To(Equal("neutral"))
+			Expect(detail.Matches[1]["id"]).To(Equal("2023-11-24-duke-wisconsin"))
+		})
+
+		It("should return a JSON error for a team without matches", func() {
+			// Act
+			var body errorBody
+			status := get("/teams/Gonzaga", &body)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusNotFound))
+			Expect(body.Error).To(Equal("no matches found for team Gonzaga"))
+		})
+
+		It("should unescape the team name", func() {
+			// Arrange
+			Expect(pSchedule.AddMatchFromString("2023-12-01,Texas A&M/Corpus Christi,1,Duke,2")).To(Succeed())
+
+			// Act
+			var detail map[string]any
+			status := get("/teams/Texas%20A&M%2FCorpus%20Christi", &detail)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(detail["name"]).To(Equal("Texas A&M/Corpus Christi"))
+		})
+	})
+
+	Describe("POST /matches", func() {
+		It("should append matches sent as JSON", func() {
+			// Arrange
+			body := `[{"date": "2023-12-02T00:00:00Z", "home": {"name": "Duke", "score": 1}, "away": {"name": "Kansas", "score": 1}, "decision": "overtime"}]`
+
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "application/json", body)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(string(data)).To(MatchJSON(`{"added": 1, "total": 7}`))
+			Expect(pSchedule.GetMatches()[6].Away.Name).To(Equal("Kansas"))
+		})
+
+		It("should append matches sent as CSV", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "text/csv", "2023-12-02,Duke,1,Kansas,0\n2023-12-03,Kansas,2,UConn,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(string(data)).To(MatchJSON(`{"added": 2, "total": 8}`))
+		})
+
+		It("should store nothing when a match is invalid", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "text/csv", "2023-12-02,Duke,1,Kansas,0\n2023-12-03,Kansas,two,UConn,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(string(data)).To(MatchJSON(`{"error": "request:2: unable to parse match: invalid home score <two>"}`))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(6))
+		})
+
+		It("should reject a match whose ID is taken", func() {
+			// Arrange
+			body := `[{"id": "final", "home": {"name": "Duke", "score": 1}, "away": {"name": "Kansas", "score": 0}}, {"id": "2023-11-06-uconn-kansas", "home": {"name": "UConn", "score": 1}, "away": {"name": "Kansas", "score": 0}}]`
+
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "application/json", body)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusConflict))
+			Expect(string(data)).To(MatchJSON(`{"error": "a match with id 2023-11-06-uconn-kansas already exists"}`))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(6))
+		})
+
+		It("should report a repeated match", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "text/csv", "2023-11-06,Kansas,57,UConn,64\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(string(data)).To(MatchJSON(`{"added": 1, "total": 7, "issues": ["2023-11-06 Kansas,57,UConn,64: mirrored duplicate of 2023-11-06 UConn,64,Kansas,57"]}`))
+		})
+
+		It("should drop a repeated match under keep-first", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches?duplicates=keep-first", "text/csv", "2023-11-06,Kansas,57,UConn,64\n2023-12-02,Duke,1,Kansas,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(string(data)).To(MatchJSON(`{"added": 1, "total": 7, "issues": ["2023-11-06 Kansas,57,UConn,64: mirrored duplicate of 2023-11-06 UConn,64,Kansas,57"]}`))
+		})
+
+		It("should store nothing when rejecting a repeated match", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches?duplicates=reject", "text/csv", "2023-12-02,Duke,1,Kansas,0\n2023-11-06,UConn,64,Kansas,58\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusConflict))
+			Expect(string(data)).To(MatchJSON(`{"error": "2023-11-06 UConn,64,Kansas,58: conflicts with 2023-11-06 UConn,64,Kansas,57"}`))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(6))
+		})
+
+		It("should reject an unknown policy", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches?duplicates=ignore", "text/csv", "2023-12-02,Duke,1,Kansas,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(string(data)).To(MatchJSON(`{"error": "invalid duplicates <ignore>"}`))
+		})
+
+		It("should reject a JSON match without team names", func() {
+			// Act
+			status, _, data := request(http.MethodPost, "/matches", "application/json", `[{"home": {"score": 1}}]`)
+
+			// Assert
+			Expect(status).To(Equal(http.StatusBadRequest))
+			Expect(string(data)).To(MatchJSON(`{"error": "match 1: both team names are required"}`))
+		})
+
+		It("should resolve team names through the registry", func() {
+			// Arrange
+			registry := team.NewRegistry()
+			for _, name := range []string{"UConn", "Kansas", "Duke", "Wisconsin"} {
+				Expect(registry.Register(team.NewTeam(name))).To(Succeed())
+			}
+			Expect(registry.AddAlias("Kansas", "KU")).To(Succeed())
+			pSchedule.SetRegistry(registry)
+			body := `[{"date": "2023-12-02T00:00:00Z", "home": {"name": "duke", "score": 1}, "away": {"name": "KU", "score": 0}}]`
+
+			// Act
+			status, _, _ := request(http.MethodPost, "/matches", "application/json", body)
+			csvStatus, _, data := request(http.MethodPost, "/matches", "text/csv", "2023-12-03,Gonzaga,2,UConn,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+			Expect(pSchedule.GetMatches()[6].Home.Name).To(Equal("Duke"))
+			Expect(pSchedule.GetMatches()[6].Away.Name).To(Equal("Kansas"))
+			Expect(csvStatus).To(Equal(http.StatusBadRequest))
+			Expect(string(data)).To(MatchJSON(`{"error": "request:1: unable to parse match: unknown team Gonzaga"}`))
+		})
+	})
+
+	Describe("PUT /matches", func() {
+		It("should keep every match when a replacement is rejected", func() {
+			// Act
+			status, _, _ := request(http.MethodPut, "/matches?duplicates=reject", "text/csv", "2023-12-02,Duke,1,Kansas,0\n2023-12-02,Kansas,0,Duke,1\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusConflict))
+			Expect(pSchedule.GetTotalMatchesPlayed()).To(Equal(6))
+		})
+
+		It("should replace every match", func() {
+			// Act
+			status, _, _ := request(http.MethodPut, "/matches", "text/csv", "2023-12-02,Duke,1,Kansas,0\n")
+
+			// Assert
+			Expect(status).To(Equal(http.StatusCreated))
+
+			var matches []map[string]any
+			Expect(get("/matches", &matches)).To(Equal(http.StatusOK))
+			Expect(matches).To(HaveLen(1))
+			Expect(matches[0]["home"]).To(HaveKeyWithValue("name", "Duke"))
+		})
+	})
+
+	It("should reject an unsupported method", func() {
+		// Act
+		status, header, data := request(http.MethodDelete, "/rankings", "", "")
+
+		// Assert
+		Expect(status).To(Equal(http.StatusMethodNotAllowed))
+		Expect(header.Get("Allow")).To(Equal("GET"))
+		Expect(string(data)).To(MatchJSON(`{"error": "method DELETE is not allowed"}`))
+	})
+
+	It("should return a JSON error for an unknown endpoint", func() {
+		// Act
+		var body errorBody
+		status := get("/standings", &body)
+
+		// Assert
+		Expect(status).To(Equal(http.StatusNotFound))
+		Expect(body.Error).To(Equal("no such endpoint /standings"))
+	})
+})
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/schedule"
+)
+
+// teamSummary is a team's entry in the team list.
+type teamSummary struct {
+	Name   string          `json:"name"`
+	Record schedule.Record `json:"record"`
+}
+
+func (srv *Server) handleTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	s := srv.schedule.Snapshot()
+
+	teams := make([]teamSummary, 0)
+	for _, name := range s.GetTeams() {
+		record, err := s.GetRecord(name)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		teams = append(teams, teamSummary{Name: name, Record: record})
+	}
+
+	writeJSON(w, http.StatusOK, teams)
+}
+
+// teamMatch is a match from the point of view of one team.
+type teamMatch struct {
+	ID       string `json:"id"`
+	Date     string `json:"date"`
+	Opponent string `json:"opponent"`
+	Location string `json:"location"`
+	Result   string `json:"result"`
+	Score    string `json:"score"`
+}
+
+// teamDetail is a team's record, splits and RPI breakdown.
+type teamDetail struct {
+	Name    string          `json:"name"`
+	Rank    int             `json:"rank"`
+	Teams   int             `json:"teams"`
+	Record  schedule.Record `json:"record"`
+	Splits  schedule.Splits `json:"splits"`
+	WP      *float64        `json:"wp"`
+	OWP     *float64        `json:"owp"`
+	OOWP    *float64        `json:"oowp"`
+	RPI     *float64        `json:"rpi"`
+	Matches []teamMatch     `json:"matches"`
+}
+
+func (srv *Server) handleTeam(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r, http.MethodGet)
+		return
+	}
+
+	name, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/teams/"))
+	if err != nil || name == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+		return
+	}
+
+	// Every part of the response comes from one snapshot so that the rating matches the listed matches.
+	s := srv.schedule.Snapshot()
+
+	if registry := s.GetRegistry(); registry != nil {
+		if t, err := registry.Resolve(name); err == nil {
+			name = t.Name
+		}
+	}
+
+	if !s.Contains(name) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no matches found for team %s", name))
+		return
+	}
+
+	if !s.IsEligible(name) {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("team %s is not eligible for the RPI", name))
+		return
+	}
+
+	detail, err := newTeamDetail(s, name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, detail)
+}
+
+// newTeamDetail returns the team's record, splits, RPI breakdown and matches in the schedule.
+func newTeamDetail(s *schedule.Schedule, name string) (*teamDetail, error) {
+	ratings, err := s.CalculateAll()
+	if err != nil {
+		return nil, err
+	}
+
+	ratings.SortByRPI()
+
+	splits, err := s.GetSplits(name)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &teamDetail{Name: name, Teams: len(ratings), Record: splits.Overall(), Splits: splits, Matches: make([]teamMatch, 0)}
+	for i, rating := range ratings {
+		if rating.Team == name {
+			detail.Rank = i + 1
+			detail.WP = number(rating.WP)
+			detail.OWP = number(rating.OWP)
+			detail.OOWP = number(rating.OOWP)
+			detail.RPI = number(rating.RPI)
+		}
+	}
+
+	rules := s.GetFormula().Decisions
+	for _, m := range s.GetMatchesForTeam(name) {
+		opponent, err := m.GetOpponent(name)
+		if err != nil {
+			return nil, err
+		}
+
+		detail.Matches = append(detail.Matches, teamMatch{
+			ID:       m.ID,
+			Date:     m.Date.Format("2006-01-02"),
+			Opponent: opponent,
+			Location: m.LocationOf(name).String(),
+			Result:   m.ResultUnder(name, rules).String(),
+			Score:    m.ToString(),
+		})
+	}
+
+	return detail, nil
+}
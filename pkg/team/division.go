@@ -0,0 +1,79 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Division is a team's NCAA division or other classification.
+type Division int
+
+const (
+	DivisionUnknown Division = iota
+	DivisionI
+	DivisionII
+	DivisionIII
+	DivisionNAIA
+	DivisionOther
+)
+
+func (d Division) String() string {
+	switch d {
+	case DivisionI:
+		return "I"
+	case DivisionII:
+		return "II"
+	case DivisionIII:
+		return "III"
+	case DivisionNAIA:
+		return "NAIA"
+	case DivisionOther:
+		return "other"
+	}
+
+	return "unknown"
+}
+
+// MarshalText encodes the division as its name.
+func (d Division) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a division from its name.  An empty name or "unknown" is DivisionUnknown.
+func (d *Division) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == DivisionUnknown.String() {
+		*d = DivisionUnknown
+		return nil
+	}
+
+	division, err := ParseDivision(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = division
+
+	return nil
+}
+
+// ParseDivision parses a division such as "I", "D1", "Division II", "3" or "NAIA".
+func ParseDivision(value string) (Division, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(value), ""))
+	normalized = strings.TrimPrefix(normalized, "DIVISION")
+	normalized = strings.TrimPrefix(normalized, "D")
+
+	switch normalized {
+	case "I", "1":
+		return DivisionI, nil
+	case "II", "2":
+		return DivisionII, nil
+	case "III", "3":
+		return DivisionIII, nil
+	case "NAIA":
+		return DivisionNAIA, nil
+	case "OTHER", "NJCAA", "JUCO", "NONE":
+		return DivisionOther, nil
+	}
+
+	return DivisionUnknown, fmt.Errorf("unknown division <%s>", value)
+}
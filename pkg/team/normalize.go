@@ -0,0 +1,82 @@
+package team
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize returns the form team names are compared in.  It lowercases the name, strips accents,
+// drops apostrophes, treats other punctuation as spaces, spells "&" as "and", and expands a leading
+// "St" to "saint" and a trailing "St" to "state", so that "St. John's", "Saint Johns" and "ST JOHNS"
+// all become "saint johns".
+func Normalize(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’':
+		case r == '&':
+			b.WriteString(" and ")
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for i, word := range words {
+		if word != "st" {
+			continue
+		}
+
+		switch {
+		case i == 0:
+			words[i] = "saint"
+		case i == len(words)-1:
+			words[i] = "state"
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Similarity returns how alike two names are once normalized, from 0 for nothing in common to 1 for
+// the same name, as one minus their edit distance divided by the length of the longer name.
+func Similarity(a, b string) float64 {
+	x, y := []rune(Normalize(a)), []rune(Normalize(b))
+
+	longest := max(len(x), len(y))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(distance(x, y))/float64(longest)
+}
+
+// distance returns the Levenshtein distance between two strings, the fewest insertions, deletions and
+// substitutions that turn one into the other.
+func distance(x, y []rune) int {
+	previous := make([]int, len(y)+1)
+	current := make([]int, len(y)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		current[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(y)]
+}
@@ -0,0 +1,297 @@
+package team
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Registry records every known team with its ID, aliases, conference, division and region, and
+// resolves the names teams are reported under to their canonical names.
+type Registry struct {
+	teams map[string]*Team
+	ids   map[string]*Team
+	names map[string]*Team
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		teams: make(map[string]*Team),
+		ids:   make(map[string]*Team),
+		names: make(map[string]*Team),
+	}
+}
+
+// nameKey is the form names and aliases are compared in.
+func nameKey(name string) string {
+	return Normalize(name)
+}
+
+// Register adds the team to the registry, replacing any team already registered with the same name.
+// A team without an ID is given one built from its name.  It returns an error when the team's ID,
+// name or one of its aliases is already used by another team.
+func (r *Registry) Register(t *Team) error {
+	if t == nil || strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("the specified team name is empty")
+	}
+
+	if t.ID == "" {
+		t.ID = Slug(t.Name)
+	}
+
+	replaced := r.teams[t.Name]
+
+	if other := r.ids[t.ID]; other != nil && other != replaced {
+		return fmt.Errorf("the id %s is already used by %s", t.ID, other.Name)
+	}
+
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		if other := r.names[nameKey(name)]; other != nil && other != replaced {
+			return fmt.Errorf("the name %s is already used by %s", name, other.Name)
+		}
+	}
+
+	if replaced != nil {
+		r.unregister(replaced)
+	}
+
+	r.teams[t.Name] = t
+	r.ids[t.ID] = t
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		r.names[nameKey(name)] = t
+	}
+
+	return nil
+}
+
+func (r *Registry) unregister(t *Team) {
+	delete(r.teams, t.Name)
+	delete(r.ids, t.ID)
+	for _, name := range append([]string{t.Name}, t.Aliases...) {
+		delete(r.names, nameKey(name))
+	}
+}
+
+// Resolve returns the team with the specified name, alias or ID.  Names and aliases are matched in
+// their normalized form, so differences in case, punctuation and accents are ignored.
+func (r *Registry) Resolve(name string) (*Team, error) {
+	if t := r.names[nameKey(name)]; t != nil {
+		return t, nil
+	}
+
+	if t := r.ids[name]; t != nil {
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("unknown team %s", name)
+}
+
+// AddAlias records another name the team with the specified name is reported under.  It returns an
+// error when the team isn't registered or the alias is already used by another team.
+func (r *Registry) AddAlias(teamName, alias string) error {
+	t, ok := r.teams[teamName]
+	if !ok {
+		return fmt.Errorf("unknown team %s", teamName)
+	}
+
+	key := nameKey(alias)
+	if key == "" {
+		return fmt.Errorf("the specified alias is empty")
+	}
+
+	if other := r.names[key]; other != nil {
+		if other == t {
+			return nil
+		}
+
+		return fmt.Errorf("the name %s is already used by %s", alias, other.Name)
+	}
+
+	t.Aliases = append(t.Aliases, alias)
+	r.names[key] = t
+
+	return nil
+}
+
+// Get returns the team with the specified name, alias or ID, or nil if the team isn't registered.
+func (r *Registry) Get(teamName string) *Team {
+	t, _ := r.Resolve(teamName)
+	return t
+}
+
+// Division returns the division of the specified team or DivisionUnknown if the team isn't registered.
+func (r *Registry) Division(teamName string) Division {
+	if t := r.Get(teamName); t != nil {
+		return t.Division
+	}
+
+	return DivisionUnknown
+}
+
+// Conference returns the conference of the specified team or an empty string if the team isn't
+// registered or has no conference.
+func (r *Registry) Conference(teamName string) string {
+	if t := r.Get(teamName); t != nil {
+		return t.Conference
+	}
+
+	return ""
+}
+
+// Region returns the region of the specified team or an empty string if the team isn't registered or
+// has no region.
+func (r *Registry) Region(teamName string) string {
+	if t := r.Get(teamName); t != nil {
+		return t.Region
+	}
+
+	return ""
+}
+
+// IsEligible reports whether matches against the specified team count toward the RPI, which only
+// considers games between Division I teams.  Teams that aren't registered are not eligible.
+func (r *Registry) IsEligible(teamName string) bool {
+	return r.Division(teamName) == DivisionI
+}
+
+// Names returns the name of every registered team in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.teams))
+	for name := range r.teams {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Teams returns every registered team in alphabetical order of name.
+func (r *Registry) Teams() []*Team {
+	teams := make([]*Team, 0, len(r.teams))
+	for _, name := range r.Names() {
+		teams = append(teams, r.teams[name])
+	}
+
+	return teams
+}
+
+// Len returns the number of registered teams.
+func (r *Registry) Len() int {
+	return len(r.teams)
+}
+
+// registryColumns is the columns a teams file may name in a header row.
+var registryColumns = []string{"id", "name", "aliases", "conference", "division", "region"}
+
+// LoadRegistry reads a registry with one team per line in the form name,division[,conference[,region]].
+// A header row naming the columns id, name, aliases, conference, division and region may be used
+// instead to give them in any order, with aliases separated by |.  Names containing commas must be
+// quoted.  Blank lines and lines starting with # are ignored.
+func LoadRegistry(reader io.Reader) (*Registry, error) {
+	registry := NewRegistry()
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	var header map[string]int
+	for first := true; ; first = false {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		lineNumber, _ := csvReader.FieldPos(0)
+
+		if first {
+			if header, err = readRegistryHeader(record); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			if header != nil {
+				continue
+			}
+		}
+
+		t, err := parseTeam(record, header)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		if err = registry.Register(t); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+
+	return registry, nil
+}
+
+// readRegistryHeader returns the index of each column named in the record, or nil when the record
+// isn't a header row because it doesn't name the name column.
+func readRegistryHeader(record []string) (map[string]int, error) {
+	header := make(map[string]int, len(record))
+	for i, field := range record {
+		header[strings.ToLower(strings.TrimSpace(field))] = i
+	}
+
+	if _, ok := header["name"]; !ok {
+		return nil, nil
+	}
+
+	for column := range header {
+		if !slices.Contains(registryColumns, column) {
+			return nil, fmt.Errorf("unknown column %s", column)
+		}
+	}
+
+	return header, nil
+}
+
+// parseTeam builds a team from a record, using the header when there is one and the positional
+// columns name,division[,conference[,region]] otherwise.
+func parseTeam(record []string, header map[string]int) (*Team, error) {
+	positional := header == nil
+	if positional {
+		if len(record) < 2 || len(record) > 4 {
+			return nil, fmt.Errorf("expected name,division but found %q", strings.Join(record, ","))
+		}
+
+		header = map[string]int{"name": 0, "division": 1, "conference": 2, "region": 3}
+	}
+
+	field := func(column string) string {
+		if i, ok := header[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	t := NewTeam(field("name"))
+	t.ID = field("id")
+	t.Conference = field("conference")
+	t.Region = field("region")
+
+	for _, alias := range strings.Split(field("aliases"), "|") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			t.Aliases = append(t.Aliases, alias)
+		}
+	}
+
+	if division := field("division"); division != "" || positional {
+		var err error
+		if t.Division, err = ParseDivision(division); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,255 @@
+package team_test
+
+import (
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	Describe("ParseDivision", func() {
+		DescribeTable("parses a division",
+			func(value string, expected team.Division) {
+				// Act
+				division, err := team.ParseDivision(value)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(division).To(Equal(expected))
+			},
+			Entry("roman numeral", "I", team.DivisionI),
+			Entry("digit", "2", team.DivisionII),
+			Entry("abbreviation", "d3", team.DivisionIII),
+			Entry("long form", "Division I", team.DivisionI),
+			Entry("NAIA", "naia", team.DivisionNAIA),
+			Entry("junior college", "NJCAA", team.DivisionOther),
+		)
+
+		It("returns an error for an unknown division", func() {
+			// Act
+			_, err := team.ParseDivision("IV")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("unknown division <IV>"))
+		})
+	})
+
+	Describe("IsEligible", func() {
+		var registry *team.Registry
+
+		BeforeEach(func() {
+			registry = team.NewRegistry()
+
+			duke := team.NewTeam("Duke")
+			duke.Division = team.DivisionI
+			Expect(registry.Register(duke)).To(Succeed())
+
+			emory := team.NewTeam("Emory")
+			emory.Division = team.DivisionIII
+			Expect(registry.Register(emory)).To(Succeed())
+		})
+
+		It("is true for a Division I team", func() {
+			Expect(registry.IsEligible("Duke")).To(BeTrue())
+		})
+
+		It("is false for a team in another division", func() {
+			Expect(registry.IsEligible("Emory")).To(BeFalse())
+		})
+
+		It("is false for a team that isn't registered", func() {
+			Expect(registry.IsEligible("Anywhere")).To(BeFalse())
+			Expect(registry.Division("Anywhere")).To(Equal(team.DivisionUnknown))
+		})
+	})
+
+	Describe("Register", func() {
+		var registry *team.Registry
+		var unc *team.Team
+
+		BeforeEach(func() {
+			registry = team.NewRegistry()
+
+			unc = team.NewTeam("North Carolina")
+			unc.Aliases = []string{"UNC", "Carolina"}
+			unc.Conference = "ACC"
+			unc.Division = team.DivisionI
+			unc.Region = "Southeast"
+			Expect(registry.Register(unc)).To(Succeed())
+		})
+
+		It("rejects a team without a name", func() {
+			// Act
+			err := team.NewRegistry().Register(team.NewTeam(" "))
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("the specified team name is empty"))
+		})
+
+		It("gives a team without an ID one built from its name", func() {
+			Expect(unc.ID).To(Equal("north-carolina"))
+		})
+
+		DescribeTable("resolves a team by name, alias or ID",
+			func(name string) {
+				// Act
+				t, err := registry.Resolve(name)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(t).To(BeIdenticalTo(unc))
+			},
+			Entry("canonical name", "North Carolina"),
+			Entry("alias", "UNC"),
+			Entry("different case and spacing", " north   CAROLINA "),
+			Entry("id", "north-carolina"),
+		)
+
+		It("returns an error for an unknown team", func() {
+			// Act
+			t, err := registry.Resolve("NC State")
+
+			// Assert
+			Expect(t).To(BeNil())
+			Expect(err).To(MatchError("unknown team NC State"))
+		})
+
+		It("looks up a team's details by alias", func() {
+			Expect(registry.Conference("UNC")).To(Equal("ACC"))
+			Expect(registry.Region("Carolina")).To(Equal("Southeast"))
+			Expect(registry.IsEligible("UNC")).To(BeTrue())
+			Expect(registry.Conference("Duke")).To(BeEmpty())
+		})
+
+		It("rejects an alias used by another team", func() {
+			// Arrange
+			usc := team.NewTeam("South Carolina")
+			usc.Aliases = []string{"carolina"}
+
+			// Act
+			err := registry.Register(usc)
+
+			// Assert
+			Expect(err).To(MatchError("the name carolina is already used by North Carolina"))
+			Expect(registry.Len()).To(Equal(1))
+		})
+
+		It("rejects an ID used by another team", func() {
+			// Arrange
+			duke := team.NewTeam("Duke")
+			duke.ID = "north-carolina"
+
+			// Act
+			err := registry.Register(duke)
+
+			// Assert
+			Expect(err).To(MatchError("the id north-carolina is already used by North Carolina"))
+		})
+
+		It("replaces a team registered with the same name", func() {
+			// Arrange
+			replacement := team.NewTeam("North Carolina")
+			replacement.Aliases = []string{"Tar Heels"}
+
+			// Act
+			err := registry.Register(replacement)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Len()).To(Equal(1))
+			Expect(registry.Get("Tar Heels")).To(BeIdenticalTo(replacement))
+			Expect(registry.Get("UNC")).To(BeNil())
+		})
+	})
+
+	Describe("LoadRegistry", func() {
+		It("reads a team per line", func() {
+			// Arrange
+			input := "# teams\nDuke,I\n\nEmory, III\nLife Pacific,NAIA\n"
+
+			// Act
+			registry, err := team.LoadRegistry(strings.NewReader(input))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Len()).To(Equal(3))
+			Expect(registry.Names()).To(Equal([]string{"Duke", "Emory", "Life Pacific"}))
+			Expect(registry.Division("Emory")).To(Equal(team.DivisionIII))
+		})
+
+		It("reads the optional conference and region columns", func() {
+			// Arrange
+			input := "Duke,I,ACC,Southeast\n\"California, Berkeley\",I,ACC\n"
+
+			// Act
+			registry, err := team.LoadRegistry(strings.NewReader(input))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Get("Duke").Region).To(Equal("Southeast"))
+			Expect(registry.Conference("California, Berkeley")).To(Equal("ACC"))
+		})
+
+		It("reads the columns named by a header row", func() {
+			// Arrange
+			input := "Name,ID,Aliases,Conference,Division,Region\n" +
+				"North Carolina,unc,UNC|Carolina,ACC,I,Southeast\n" +
+				"Emory,,,UAA,,\n"
+
+			// Act
+			registry, err := team.LoadRegistry(strings.NewReader(input))
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(registry.Names()).To(Equal([]string{"Emory", "North Carolina"}))
+			Expect(*registry.Get("Carolina")).To(Equal(team.Team{
+				ID:         "unc",
+				Name:       "North Carolina",
+				Aliases:    []string{"UNC", "Carolina"},
+				Conference: "ACC",
+				Division:   team.DivisionI,
+				Region:     "Southeast",
+			}))
+			Expect(registry.Get("Emory").ID).To(Equal("emory"))
+			Expect(registry.Division("Emory")).To(Equal(team.DivisionUnknown))
+		})
+
+		It("reports an unknown header column", func() {
+			// Act
+			_, err := team.LoadRegistry(strings.NewReader("name,mascot\n"))
+
+			// Assert
+			Expect(err).To(MatchError("line 1: unknown column mascot"))
+		})
+
+		It("reports an alias used by another team", func() {
+			// Act
+			_, err := team.LoadRegistry(strings.NewReader("name,aliases\nNorth Carolina,UNC\nNorth Carolina Central,UNC\n"))
+
+			// Assert
+			Expect(err).To(MatchError("line 3: the name UNC is already used by North Carolina"))
+		})
+
+		It("reports the line of an invalid division", func() {
+			// Act
+			_, err := team.LoadRegistry(strings.NewReader("Duke,I\nEmory,IV\n"))
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("line 2: unknown division <IV>"))
+		})
+
+		It("reports a line without a division", func() {
+			// Act
+			_, err := team.LoadRegistry(strings.NewReader("Duke\n"))
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`line 1: expected name,division but found "Duke"`))
+		})
+	})
+})
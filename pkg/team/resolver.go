@@ -0,0 +1,171 @@
+package team
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Decision is how a reported team name was mapped to a registered team.
+type Decision int
+
+const (
+	// DecisionExact is a name that is a registered name, alias or ID.
+	DecisionExact Decision = iota
+	// DecisionApplied is a name close enough to a registered name to be added as one of its aliases.
+	DecisionApplied
+	// DecisionSuggested is a name with a close registered name that wasn't close enough to be applied.
+	DecisionSuggested
+	// DecisionUnknown is a name without any close registered name.
+	DecisionUnknown
+)
+
+func (d Decision) String() string {
+	switch d {
+	case DecisionExact:
+		return "exact"
+	case DecisionApplied:
+		return "applied"
+	case DecisionSuggested:
+		return "suggested"
+	case DecisionUnknown:
+		return "unknown"
+	}
+
+	return "unknown"
+}
+
+// Mapping records the decision made about a reported team name.
+type Mapping struct {
+	// Name is the name as it was reported and Count is the number of times it was resolved.
+	Name  string
+	Count int
+
+	Decision Decision
+
+	// Team is the registered team the name was mapped to, or the closest registered team when the name
+	// was suggested, and Confidence is how alike the names are.
+	Team       *Team
+	Confidence float64
+}
+
+func (m Mapping) String() string {
+	switch m.Decision {
+	case DecisionExact, DecisionApplied:
+		return fmt.Sprintf("%s -> %s (%s, %.2f)", m.Name, m.Team.Name, m.Decision, m.Confidence)
+	case DecisionSuggested:
+		return fmt.Sprintf("%s -> %s? (%s, %.2f)", m.Name, m.Team.Name, m.Decision, m.Confidence)
+	}
+
+	return fmt.Sprintf("%s (%s)", m.Name, m.Decision)
+}
+
+// Review is every mapping decision made by a resolver, in the order the names were first seen.
+type Review []*Mapping
+
+// Write writes the review as a table with a row for each name.
+func (r Review) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tTEAM\tDECISION\tCONFIDENCE\tCOUNT")
+
+	for _, m := range r {
+		teamName := "-"
+		if m.Team != nil {
+			teamName = m.Team.Name
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%d\n", m.Name, teamName, m.Decision, m.Confidence, m.Count)
+	}
+
+	return tw.Flush()
+}
+
+// minSuggestion is the least similarity a registered name needs to be suggested for a name.
+const minSuggestion = 0.5
+
+// Resolver resolves reported team names through a registry, matching names that aren't registered to
+// the most similar registered name.  A name whose similarity is at least the threshold is added to the
+// registry as an alias of that team; any other unregistered name is an error.  A resolver records each
+// decision it makes for review and isn't safe for concurrent use.
+type Resolver struct {
+	registry  *Registry
+	threshold float64
+	mappings  map[string]*Mapping
+	review    Review
+}
+
+// NewResolver returns a resolver that applies aliases with a similarity of at least threshold, which
+// should be between 0 and 1.  A threshold above 1 never applies an alias and only makes suggestions.
+func NewResolver(registry *Registry, threshold float64) *Resolver {
+	return &Resolver{
+		registry:  registry,
+		threshold: threshold,
+		mappings:  make(map[string]*Mapping),
+	}
+}
+
+// Resolve returns the registered team for the reported name.
+func (r *Resolver) Resolve(name string) (*Team, error) {
+	m, ok := r.mappings[name]
+	if !ok {
+		m = r.decide(name)
+		r.mappings[name] = m
+		r.review = append(r.review, m)
+	}
+
+	m.Count++
+
+	switch m.Decision {
+	case DecisionExact, DecisionApplied:
+		return m.Team, nil
+	case DecisionSuggested:
+		return nil, fmt.Errorf("unknown team %s, did you mean %s?", name, m.Team.Name)
+	}
+
+	return nil, fmt.Errorf("unknown team %s", name)
+}
+
+// Review returns every decision the resolver has made.
+func (r *Resolver) Review() Review {
+	return r.review
+}
+
+func (r *Resolver) decide(name string) *Mapping {
+	if t, err := r.registry.Resolve(name); err == nil {
+		return &Mapping{Name: name, Decision: DecisionExact, Team: t, Confidence: 1}
+	}
+
+	best, confidence, ambiguous := r.registry.closest(name)
+	if best == nil || confidence < minSuggestion {
+		return &Mapping{Name: name, Decision: DecisionUnknown}
+	}
+
+	m := &Mapping{Name: name, Decision: DecisionSuggested, Team: best, Confidence: confidence}
+	if confidence >= r.threshold && !ambiguous && r.registry.AddAlias(best.Name, name) == nil {
+		m.Decision = DecisionApplied
+	}
+
+	return m
+}
+
+// closest returns the registered team with the name or alias most similar to the name, and whether
+// another team is just as similar.
+func (r *Registry) closest(name string) (*Team, float64, bool) {
+	var best *Team
+	var confidence float64
+	var ambiguous bool
+
+	for _, t := range r.Teams() {
+		for _, candidate := range append([]string{t.Name}, t.Aliases...) {
+			similarity := Similarity(name, candidate)
+			switch {
+			case similarity > confidence:
+				best, confidence, ambiguous = t, similarity, false
+			case similarity == confidence && t != best:
+				ambiguous = true
+			}
+		}
+	}
+
+	return best, confidence, ambiguous
+}
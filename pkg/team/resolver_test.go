@@ -0,0 +1,165 @@
+package team_test
+
+import (
+	"bytes"
+
+	"github.com/jedi-knights/rpi/pkg/team"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolver", func() {
+	var registry *team.Registry
+
+	BeforeEach(func() {
+		registry = team.NewRegistry()
+		for _, name := range []string{"St. John's", "Ohio St.", "Texas A&M", "San José State", "Miami (FL)", "Miami (OH)"} {
+			Expect(registry.Register(team.NewTeam(name))).To(Succeed())
+		}
+	})
+
+	Describe("Normalize", func() {
+		DescribeTable("normalizes a name",
+			func(name, expected string) {
+				// Act
+				normalized := team.Normalize(name)
+
+				// Assert
+				Expect(normalized).To(Equal(expected))
+			},
+			Entry("abbreviated saint", "St. John's", "saint johns"),
+			Entry("spelled out saint", "Saint Johns", "saint johns"),
+			Entry("upper case", "ST JOHNS", "saint johns"),
+			Entry("curly apostrophe", "St. John’s", "saint johns"),
+			Entry("abbreviated state", "Ohio St.", "ohio state"),
+			Entry("ampersand", "Texas A&M", "texas a and m"),
+			Entry("accents", "San José State", "san jose state"),
+			Entry("qualifier", "Saint John's (NY)", "saint johns ny"),
+		)
+	})
+
+	Describe("Similarity", func() {
+		It("is one for names that normalize the same", func() {
+			// Act
+			similarity := team.Similarity("St. John's", "saint johns")
+
+			// Assert
+			Expect(similarity).To(Equal(1.0))
+		})
+
+		It("is the share of the longer name that doesn't need an edit", func() {
+			// Act
+			similarity := team.Similarity("Saint John's (NY)", "St. John's")
+
+			// Assert
+			// "saint johns ny" is three insertions away from "saint johns".
+			Expect(similarity).To(BeNumerically("~", 1-3.0/14, 1e-9))
+		})
+	})
+
+	Describe("Registry", func() {
+		It("resolves names that normalize the same as a registered name", func() {
+			// Act
+			t, err := registry.Resolve("Saint Johns")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Name).To(Equal("St. John's"))
+		})
+
+		It("rejects an alias used by another team", func() {
+			// Act
+			err := registry.AddAlias("Ohio St.", "st johns")
+
+			// Assert
+			Expect(err).To(MatchError("the name st johns is already used by St. John's"))
+		})
+	})
+
+	Describe("Resolve", func() {
+		It("resolves registered names exactly", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.8)
+
+			// Act
+			t, err := resolver.Resolve("ohio state")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Name).To(Equal("Ohio St."))
+			Expect(resolver.Review()).To(HaveLen(1))
+			Expect(resolver.Review()[0].Decision).To(Equal(team.DecisionExact))
+		})
+
+		It("applies a close name as an alias", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.75)
+
+			// Act
+			t, err := resolver.Resolve("Saint John's (NY)")
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t.Name).To(Equal("St. John's"))
+			Expect(t.Aliases).To(ContainElement("Saint John's (NY)"))
+			Expect(registry.Get("SAINT JOHNS NY")).To(Equal(t))
+			Expect(resolver.Review()[0].Decision).To(Equal(team.DecisionApplied))
+		})
+
+		It("suggests a name below the threshold", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.9)
+
+			// Act
+			_, err := resolver.Resolve("Saint John's (NY)")
+
+			// Assert
+			Expect(err).To(MatchError("unknown team Saint John's (NY), did you mean St. John's?"))
+			Expect(resolver.Review()[0].Decision).To(Equal(team.DecisionSuggested))
+			Expect(registry.Get("Saint John's (NY)")).To(BeNil())
+		})
+
+		It("doesn't apply a name equally close to two teams", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.5)
+
+			// Act
+			_, err := resolver.Resolve("Miami")
+
+			// Assert
+			Expect(err).To(HaveOccurred())
+			Expect(resolver.Review()[0].Decision).To(Equal(team.DecisionSuggested))
+		})
+
+		It("reports a name without a close team as unknown", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.8)
+
+			// Act
+			_, err := resolver.Resolve("Gonzaga")
+
+			// Assert
+			Expect(err).To(MatchError("unknown team Gonzaga"))
+			Expect(resolver.Review()[0].Decision).To(Equal(team.DecisionUnknown))
+		})
+
+		It("writes a review of every decision", func() {
+			// Arrange
+			resolver := team.NewResolver(registry, 0.75)
+			for _, name := range []string{"St Johns", "Saint John's (NY)", "St Johns", "Gonzaga"} {
+				_, _ = resolver.Resolve(name)
+			}
+			var buffer bytes.Buffer
+
+			// Act
+			err := resolver.Review().Write(&buffer)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(MatchRegexp(`NAME\s+TEAM\s+DECISION\s+CONFIDENCE\s+COUNT\n`))
+			Expect(buffer.String()).To(MatchRegexp(`St Johns\s+St\. John's\s+exact\s+1\.00\s+2\n`))
+			Expect(buffer.String()).To(MatchRegexp(`Saint John's \(NY\)\s+St\. John's\s+applied\s+0\.79\s+1\n`))
+			Expect(buffer.String()).To(MatchRegexp(`Gonzaga\s+-\s+unknown\s+0\.00\s+1\n`))
+		})
+	})
+})
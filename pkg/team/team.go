@@ -1,9 +1,25 @@
 package team
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 type Team struct {
-	Name   string
+	// ID identifies the team across data sources.  A registry gives a team without one an ID built
+	// from its name.
+	ID string
+
+	// Name is the team's canonical name and Aliases are the other names it is reported under, such as
+	// "UNC" for North Carolina.
+	Name    string
+	Aliases []string
+
+	Conference string
+	Division   Division
+	Region     string
+
 	Wins   int
 	Losses int
 	Ties   int
@@ -31,3 +47,13 @@ func (t *Team) UpdateRecord(teamScore, opponentScore int) {
 func (t *Team) ToString() string {
 	return fmt.Sprintf("%s (%d-%d-%d)", t.Name, t.Wins, t.Losses, t.Ties)
 }
+
+// Slug lowercases the name and replaces each run of characters other than letters and digits with a
+// hyphen, so that "Texas A&M" becomes "texas-a-m".
+func Slug(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, "-")
+}
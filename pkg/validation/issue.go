@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Kind is the kind of problem found with a match.
+type Kind int
+
+const (
+	// KindDuplicate is a match reported again with the same home and away teams and score.
+	KindDuplicate Kind = iota
+	// KindMirrored is a match reported again with the home and away teams swapped.
+	KindMirrored
+	// KindConflict is a match between the same teams on the same date with a different result.
+	KindConflict
+	// KindSelfMatch is a match in which a team plays itself.
+	KindSelfMatch
+	// KindEmptyName is a match with a team that has no name.
+	KindEmptyName
+	// KindNegativeScore is a match with a score below zero.
+	KindNegativeScore
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindDuplicate:
+		return "duplicate"
+	case KindMirrored:
+		return "mirrored duplicate"
+	case KindConflict:
+		return "conflicting result"
+	case KindSelfMatch:
+		return "self match"
+	case KindEmptyName:
+		return "empty team name"
+	case KindNegativeScore:
+		return "negative score"
+	}
+
+	return "unknown"
+}
+
+// IsRepeat reports whether the kind describes a match that repeats an earlier one.
+func (k Kind) IsRepeat() bool {
+	return k == KindDuplicate || k == KindMirrored || k == KindConflict
+}
+
+// Issue is a problem found with a match.  Other is the earlier match it repeats, when it repeats one.
+type Issue struct {
+	Kind  Kind
+	Match *match.Match
+	Other *match.Match
+}
+
+func (i *Issue) Error() string {
+	return fmt.Sprintf("%s: %s", describe(i.Match), i.detail())
+}
+
+func (i *Issue) detail() string {
+	m := i.Match
+
+	switch i.Kind {
+	case KindDuplicate, KindMirrored:
+		return fmt.Sprintf("%s of %s", i.Kind, describe(i.Other))
+	case KindConflict:
+		return fmt.Sprintf("conflicts with %s", describe(i.Other))
+	case KindSelfMatch:
+		return fmt.Sprintf("%s can't play itself", m.Home.Name)
+	case KindEmptyName:
+		if strings.TrimSpace(m.Home.Name) == "" {
+			return "the home team name is empty"
+		}
+		return "the away team name is empty"
+	case KindNegativeScore:
+		if m.Home.Score < 0 {
+			return "the home score is negative"
+		}
+		return "the away score is negative"
+	}
+
+	return i.Kind.String()
+}
+
+func describe(m *match.Match) string {
+	return fmt.Sprintf("%s %s", m.Date.Format("2006-01-02"), m.ToString())
+}
+
+// Issues is every problem found with a set of matches, in the order the matches were given.
+type Issues []*Issue
+
+func (i Issues) Error() string {
+	messages := make([]string, 0, len(i))
+	for _, issue := range i {
+		messages = append(messages, issue.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedi-knights/rpi/pkg/match"
+)
+
+// Policy is what happens to matches that have problems.
+type Policy int
+
+const (
+	// PolicyReport keeps every match and reports the problems.
+	PolicyReport Policy = iota
+	// PolicyReject keeps no matches when any match has a problem.
+	PolicyReject
+	// PolicyKeepFirst keeps the first report of a repeated match and drops invalid matches.
+	PolicyKeepFirst
+	// PolicyKeepLast keeps the last report of a repeated match and drops invalid matches.
+	PolicyKeepLast
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyReport:
+		return "report"
+	case PolicyReject:
+		return "reject"
+	case PolicyKeepFirst:
+		return "keep-first"
+	case PolicyKeepLast:
+		return "keep-last"
+	}
+
+	return "unknown"
+}
+
+// ParsePolicy returns the policy with the specified name.
+func ParsePolicy(name string) (Policy, error) {
+	for _, policy := range []Policy{PolicyReport, PolicyReject, PolicyKeepFirst, PolicyKeepLast} {
+		if strings.EqualFold(name, policy.String()) {
+			return policy, nil
+		}
+	}
+
+	return PolicyReport, fmt.Errorf("unknown policy %s", name)
+}
+
+// Validate checks each match for an empty team name, a team playing itself and a negative score, and
+// checks for matches between the same teams on the same date, which are exact duplicates, mirrored
+// duplicates with the home and away teams swapped, or conflicting results.  It returns the matches
+// kept by the policy, in the order they were given, and the problems found.  The error is the
+// problems when the policy is PolicyReject and there are any.
+//
+// Teams that really did meet more than once on the same day, such as in a baseball doubleheader, are
+// reported as repeats, so PolicyReport is the only policy suited to them.
+func Validate(matches []*match.Match, policy Policy) ([]*match.Match, Issues, error) {
+	var issues Issues
+
+	keep := make([]bool, len(matches))
+	meetings := make(map[string][]int)
+	var order []string
+
+	for i, m := range matches {
+		if issue := check(m); issue != nil {
+			issues = append(issues, issue)
+			keep[i] = policy == PolicyReport
+			continue
+		}
+
+		key := MeetingKey(m)
+		if previous := meetings[key]; len(previous) > 0 {
+			first := matches[previous[0]]
+			issues = append(issues, &Issue{Kind: compare(first, m), Match: m, Other: first})
+		} else {
+			order = append(order, key)
+		}
+
+		meetings[key] = append(meetings[key], i)
+	}
+
+	if policy == PolicyReject && len(issues) > 0 {
+		return nil, issues, issues
+	}
+
+	for _, key := range order {
+		for _, i := range kept(meetings[key], policy) {
+			keep[i] = true
+		}
+	}
+
+	valid := make([]*match.Match, 0, len(matches))
+	for i, m := range matches {
+		if keep[i] {
+			valid = append(valid, m)
+		}
+	}
+
+	return valid, issues, nil
+}
+
+// kept returns the reports of a meeting that the policy keeps.
+func kept(reports []int, policy Policy) []int {
+	switch policy {
+	case PolicyKeepFirst:
+		return reports[:1]
+	case PolicyKeepLast:
+		return reports[len(reports)-1:]
+	}
+
+	return reports
+}
+
+// check returns the problem with a single match, or nil when it has none.
+func check(m *match.Match) *Issue {
+	switch {
+	case strings.TrimSpace(m.Home.Name) == "" || strings.TrimSpace(m.Away.Name) == "":
+		return &Issue{Kind: KindEmptyName, Match: m}
+	case m.Home.Name == m.Away.Name:
+		return &Issue{Kind: KindSelfMatch, Match: m}
+	case m.Home.Score < 0 || m.Away.Score < 0:
+		return &Issue{Kind: KindNegativeScore, Match: m}
+	}
+
+	return nil
+}
+
+// MeetingKey identifies the date and pair of teams of a match regardless of which team was at home, so
+// every report of the same meeting has the same key.
+func MeetingKey(m *match.Match) string {
+	teams := []string{m.Home.Name, m.Away.Name}
+	if teams[1] < teams[0] {
+		teams[0], teams[1] = teams[1], teams[0]
+	}
+
+	return strings.Join([]string{m.Date.Format("2006-01-02"), teams[0], teams[1]}, "\x00")
+}
+
+// compare returns how a later report of a meeting relates to the first.
+func compare(first, later *match.Match) Kind {
+	if first.Decision != later.Decision || first.Overtimes != later.Overtimes || first.State != later.State {
+		return KindConflict
+	}
+
+	if first.Home == later.Home && first.Away == later.Away {
+		return KindDuplicate
+	}
+
+	if first.Home == later.Away && first.Away == later.Home {
+		return KindMirrored
+	}
+
+	return KindConflict
+}
@@ -0,0 +1,175 @@
+package validation_test
+
+import (
+	"github.com/jedi-knights/rpi/pkg/match"
+	"github.com/jedi-knights/rpi/pkg/validation"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation", func() {
+	parse := func(lines ...string) []*match.Match {
+		matches := make([]*match.Match, 0, len(lines))
+		for _, line := range lines {
+			m := match.NewMatchFromString(line)
+			Expect(m).NotTo(BeNil(), line)
+			matches = append(matches, m)
+		}
+		return matches
+	}
+
+	Describe("Validate", func() {
+		var matches []*match.Match
+
+		BeforeEach(func() {
+			matches = parse(
+				"2023-11-06,UConn,64,Kansas,57",
+				"2023-11-10,UConn,82,Duke,68",
+				"2023-11-06,UConn,64,Kansas,57",
+				"2023-11-06,Kansas,57,UConn,64",
+				"2023-11-10,Duke,70,UConn,82",
+			)
+		})
+
+		It("should find exact, mirrored and conflicting repeats", func() {
+			// Act
+			kept, issues, err := validation.Validate(matches, validation.PolicyReport)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kept).To(Equal(matches))
+			Expect(issues).To(HaveLen(3))
+
+			Expect(issues[0].Kind).To(Equal(validation.KindDuplicate))
+			Expect(issues[0].Match).To(BeIdenticalTo(matches[2]))
+			Expect(issues[0].Other).To(BeIdenticalTo(matches[0]))
+			Expect(issues[1].Kind).To(Equal(validation.KindMirrored))
+			Expect(issues[2].Kind).To(Equal(validation.KindConflict))
+			Expect(issues[2].Other).To(BeIdenticalTo(matches[1]))
+
+			Expect(issues.Error()).To(Equal(
+				"2023-11-06 UConn,64,Kansas,57: duplicate of 2023-11-06 UConn,64,Kansas,57\n" +
+					"2023-11-06 Kansas,57,UConn,64: mirrored duplicate of 2023-11-06 UConn,64,Kansas,57\n" +
+					"2023-11-10 Duke,70,UConn,82: conflicts with 2023-11-10 UConn,82,Duke,68"))
+		})
+
+		It("should keep the first report of each meeting", func() {
+			// Act
+			kept, issues, err := validation.Validate(matches, validation.PolicyKeepFirst)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(HaveLen(3))
+			Expect(kept).To(Equal([]*match.Match{matches[0], matches[1]}))
+		})
+
+		It("should keep the last report of each meeting", func() {
+			// Act
+			kept, _, err := validation.Validate(matches, validation.PolicyKeepLast)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kept).To(Equal([]*match.Match{matches[3], matches[4]}))
+		})
+
+		It("should reject every match when any has a problem", func() {
+			// Act
+			kept, issues, err := validation.Validate(matches, validation.PolicyReject)
+
+			// Assert
+			Expect(kept).To(BeNil())
+			Expect(issues).To(HaveLen(3))
+			Expect(err).To(MatchError(issues.Error()))
+		})
+
+		It("should treat a different decision as a conflict", func() {
+			// Arrange
+			matches = parse("2023-11-06,UConn,1,Kansas,1", "2023-11-06,UConn,1,Kansas,1 (4-3 PK)")
+
+			// Act
+			_, issues, err := validation.Validate(matches, validation.PolicyReport)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Kind).To(Equal(validation.KindConflict))
+		})
+
+		It("should accept matches between the same teams on different dates", func() {
+			// Arrange
+			matches = parse("2023-11-06,UConn,64,Kansas,57", "2023-11-07,UConn,64,Kansas,57")
+
+			// Act
+			kept, issues, err := validation.Validate(matches, validation.PolicyReject)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(issues).To(BeEmpty())
+			Expect(kept).To(Equal(matches))
+		})
+
+		DescribeTable("should flag an invalid match",
+			func(line string, kind validation.Kind, message string) {
+				// Arrange
+				matches = parse("2023-11-10,UConn,82,Duke,68", line)
+
+				// Act
+				kept, issues, err := validation.Validate(matches, validation.PolicyKeepFirst)
+
+				// Assert
+				Expect(err).NotTo(HaveOccurred())
+				Expect(kept).To(Equal(matches[:1]))
+				Expect(issues).To(HaveLen(1))
+				Expect(issues[0].Kind).To(Equal(kind))
+				Expect(issues[0].Error()).To(Equal(message))
+			},
+			Entry("self match", "2023-11-11,UConn,1,UConn,0", validation.KindSelfMatch,
+				"2023-11-11 UConn,1,UConn,0: UConn can't play itself"),
+			Entry("empty home team", "2023-11-11, ,1,Duke,0", validation.KindEmptyName,
+				"2023-11-11  ,1,Duke,0: the home team name is empty"),
+			Entry("empty away team", "2023-11-11,Duke,1,,0", validation.KindEmptyName,
+				"2023-11-11 Duke,1,,0: the away team name is empty"),
+			Entry("negative home score", "2023-11-11,Duke,-1,Kansas,0", validation.KindNegativeScore,
+				"2023-11-11 Duke,-1,Kansas,0: the home score is negative"),
+			Entry("negative away score", "2023-11-11,Duke,1,Kansas,-2", validation.KindNegativeScore,
+				"2023-11-11 Duke,1,Kansas,-2: the away score is negative"),
+		)
+
+		It("should keep invalid matches when reporting", func() {
+			// Arrange
+			matches = parse("2023-11-11,UConn,1,UConn,0")
+
+			// Act
+			kept, issues, err := validation.Validate(matches, validation.PolicyReport)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kept).To(Equal(matches))
+			Expect(issues).To(HaveLen(1))
+		})
+	})
+
+	DescribeTable("ParsePolicy",
+		func(name string, expected validation.Policy) {
+			// Act
+			policy, err := validation.ParsePolicy(name)
+
+			// Assert
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(expected))
+			Expect(policy.String()).To(Equal(name))
+		},
+		Entry("report", "report", validation.PolicyReport),
+		Entry("reject", "reject", validation.PolicyReject),
+		Entry("keep first", "keep-first", validation.PolicyKeepFirst),
+		Entry("keep last", "keep-last", validation.PolicyKeepLast),
+	)
+
+	It("should reject an unknown policy", func() {
+		// Act
+		_, err := validation.ParsePolicy("ignore")
+
+		// Assert
+		Expect(err).To(MatchError("unknown policy ignore"))
+	})
+})